@@ -0,0 +1,115 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const (
+	TFSwitchHomeEnvVar = "TFSWITCH_HOME" // Relocates the configuration, the installed versions and the caches at once
+	ConfigFileName     = "config.toml"   // Name of the configuration file in the configuration directory
+
+	xdgConfigHomeEnvVar = "XDG_CONFIG_HOME"
+	xdgDataHomeEnvVar   = "XDG_DATA_HOME"
+	xdgCacheHomeEnvVar  = "XDG_CACHE_HOME"
+	xdgAppDirName       = "tfswitch"
+	cacheDirName        = "cache"
+	binDirName          = "bin"
+)
+
+// LookupHomeDirectory : return the user's home directory, empty if it cannot be resolved (e.g. in containers without HOME)
+func LookupHomeDirectory() string {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		logger.Debugf("Failed to get user's home directory: %v", err)
+		return ""
+	}
+	return homeDir
+}
+
+// GetTFSwitchHome : return the directory set by TFSWITCH_HOME, empty if not set
+func GetTFSwitchHome() string {
+	return strings.TrimSpace(os.Getenv(TFSwitchHomeEnvVar))
+}
+
+// getXDGDirectory : return the tfswitch directory below the XDG base directory from envVar, empty if not set.
+// Relative paths are disregarded as per the XDG Base Directory Specification.
+func getXDGDirectory(envVar string) string {
+	baseDir := strings.TrimSpace(os.Getenv(envVar))
+	if baseDir == "" {
+		return ""
+	}
+	if !filepath.IsAbs(baseDir) {
+		logger.Warnf("Ignoring %s=%q: path must be absolute", envVar, baseDir)
+		return ""
+	}
+	return filepath.Join(baseDir, xdgAppDirName)
+}
+
+// GetConfigDirectory : return the directory of the config.toml file:
+// $TFSWITCH_HOME, $XDG_CONFIG_HOME/tfswitch or ~/.config/tfswitch; empty if none can be resolved
+func GetConfigDirectory() string {
+	if tfSwitchHome := GetTFSwitchHome(); tfSwitchHome != "" {
+		return tfSwitchHome
+	}
+	if configDir := getXDGDirectory(xdgConfigHomeEnvVar); configDir != "" {
+		return configDir
+	}
+	if homeDir := LookupHomeDirectory(); homeDir != "" {
+		return filepath.Join(homeDir, ".config", xdgAppDirName)
+	}
+	return ""
+}
+
+// GetDataDirectory : return the default install path, i.e. the directory to keep the InstallDir with installed versions in:
+// $TFSWITCH_HOME, the home directory if it already has the InstallDir, $XDG_DATA_HOME/tfswitch, or the home directory;
+// empty if none can be resolved
+func GetDataDirectory() string {
+	if tfSwitchHome := GetTFSwitchHome(); tfSwitchHome != "" {
+		return tfSwitchHome
+	}
+	homeDir := LookupHomeDirectory()
+	// Keep using versions installed before XDG_DATA_HOME was honoured
+	if homeDir != "" && CheckDirExist(filepath.Join(homeDir, InstallDir)) {
+		return homeDir
+	}
+	if dataDir := getXDGDirectory(xdgDataHomeEnvVar); dataDir != "" {
+		return dataDir
+	}
+	return homeDir
+}
+
+// GetCacheDirectory : return the directory to download release artifacts and public PGP keys to:
+// $TFSWITCH_HOME/cache or $XDG_CACHE_HOME/tfswitch; empty if neither is set
+func GetCacheDirectory() string {
+	if tfSwitchHome := GetTFSwitchHome(); tfSwitchHome != "" {
+		return filepath.Join(tfSwitchHome, cacheDirName)
+	}
+	return getXDGDirectory(xdgCacheHomeEnvVar)
+}
+
+// GetFallbackBinDirectory : return the directory to install binaries to when the bin path is not writable:
+// $TFSWITCH_HOME/bin or ~/bin; empty if neither can be resolved
+func GetFallbackBinDirectory() string {
+	if tfSwitchHome := GetTFSwitchHome(); tfSwitchHome != "" {
+		return filepath.Join(tfSwitchHome, binDirName)
+	}
+	if homeDir := LookupHomeDirectory(); homeDir != "" {
+		return filepath.Join(homeDir, binDirName)
+	}
+	return ""
+}
+
+// getDownloadLocation : get location to download release artifacts to before extracting them to the installLocation,
+// will create the cache directory if it does not exist
+func getDownloadLocation(installLocation string) string {
+	cacheDir := GetCacheDirectory()
+	if cacheDir == "" {
+		return installLocation
+	}
+	createDirIfNotExist(cacheDir)
+	return cacheDir
+}
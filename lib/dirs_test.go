@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/stretchr/testify/assert"
+)
+
+// setTestHome : point the home directory to homeDir for the duration of the test
+func setTestHome(t *testing.T, homeDir string) {
+	t.Helper()
+	disableCache := homedir.DisableCache
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = disableCache })
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+}
+
+func TestGetDirectories_tfswitch_home(t *testing.T) {
+	InitLogger("DEBUG")
+	tfSwitchHome := t.TempDir()
+	t.Setenv(TFSwitchHomeEnvVar, tfSwitchHome)
+	t.Setenv(xdgConfigHomeEnvVar, t.TempDir())
+	t.Setenv(xdgDataHomeEnvVar, t.TempDir())
+	t.Setenv(xdgCacheHomeEnvVar, t.TempDir())
+
+	assert.Equal(t, tfSwitchHome, GetConfigDirectory())
+	assert.Equal(t, tfSwitchHome, GetDataDirectory())
+	assert.Equal(t, filepath.Join(tfSwitchHome, "cache"), GetCacheDirectory())
+	assert.Equal(t, filepath.Join(tfSwitchHome, "bin"), GetFallbackBinDirectory())
+
+	installLocation := filepath.Join(tfSwitchHome, InstallDir)
+	assert.Equal(t, filepath.Join(tfSwitchHome, "cache"), getDownloadLocation(installLocation))
+	assert.True(t, CheckDirExist(filepath.Join(tfSwitchHome, "cache")))
+}
+
+func TestGetDirectories_xdg(t *testing.T) {
+	InitLogger("DEBUG")
+	homeDir := t.TempDir()
+	setTestHome(t, homeDir)
+	t.Setenv(TFSwitchHomeEnvVar, "")
+
+	t.Log("Without XDG base directories")
+	t.Setenv(xdgConfigHomeEnvVar, "")
+	t.Setenv(xdgDataHomeEnvVar, "")
+	t.Setenv(xdgCacheHomeEnvVar, "")
+	assert.Equal(t, filepath.Join(homeDir, ".config", "tfswitch"), GetConfigDirectory())
+	assert.Equal(t, homeDir, GetDataDirectory())
+	assert.Equal(t, "", GetCacheDirectory())
+	assert.Equal(t, filepath.Join(homeDir, "bin"), GetFallbackBinDirectory())
+	assert.Equal(t, filepath.Join(homeDir, InstallDir), getDownloadLocation(filepath.Join(homeDir, InstallDir)))
+
+	t.Log("With XDG base directories")
+	xdgDir := t.TempDir()
+	t.Setenv(xdgConfigHomeEnvVar, filepath.Join(xdgDir, "config"))
+	t.Setenv(xdgDataHomeEnvVar, filepath.Join(xdgDir, "data"))
+	t.Setenv(xdgCacheHomeEnvVar, filepath.Join(xdgDir, "cache"))
+	assert.Equal(t, filepath.Join(xdgDir, "config", "tfswitch"), GetConfigDirectory())
+	assert.Equal(t, filepath.Join(xdgDir, "data", "tfswitch"), GetDataDirectory())
+	assert.Equal(t, filepath.Join(xdgDir, "cache", "tfswitch"), GetCacheDirectory())
+
+	t.Log("With versions installed in the home directory before")
+	createDirIfNotExist(filepath.Join(homeDir, InstallDir))
+	assert.Equal(t, homeDir, GetDataDirectory())
+
+	t.Log("With relative XDG base directories")
+	t.Setenv(xdgConfigHomeEnvVar, "config")
+	t.Setenv(xdgCacheHomeEnvVar, "cache")
+	assert.Equal(t, filepath.Join(homeDir, ".config", "tfswitch"), GetConfigDirectory())
+	assert.Equal(t, "", GetCacheDirectory())
+}
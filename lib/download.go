@@ -24,21 +24,46 @@ func DownloadProductFromURL(product Product, installLocation, mirrorURL, tfversi
 		return "", errors.New("download URL is invalid")
 	}
 
+	hashURL := mirrorURL + "/" + product.GetChecksumsFileName(versionPrefix, tfversion)
+	artifact := ReleaseArtifact{
+		URL:                   mirrorURL + "/" + product.GetArtifactFileName(versionPrefix, tfversion, goos, goarch),
+		ChecksumsURL:          hashURL,
+		ChecksumsSignatureURL: hashURL + "." + product.GetShaSignatureSuffix(),
+	}
+	return DownloadReleaseArtifact(product, installLocation, artifact)
+}
+
+// DownloadReleaseArtifact : Downloads the release artifact and verifies its checksum (and signature thereof)
+func DownloadReleaseArtifact(product Product, installLocation string, artifact ReleaseArtifact) (string, error) {
+	if artifact.URL == "" {
+		return "", errors.New("download URL is invalid")
+	}
+	// Some products (e.g. Terragrunt) don't sign their checksums
+	isSigned := !product.HasUnsignedReleases()
+	if isSigned && len(product.GetPublicKeyURLs()) == 0 {
+		return "", fmt.Errorf("No public PGP key to verify %s release signature", product.GetName())
+	}
+
 	var wg sync.WaitGroup
 	defer wg.Done()
 	// nolint:revive // FIXME: var-naming: var zipUrl should be zipURL (revive)
-	zipUrl := mirrorURL + "/" + versionPrefix + tfversion + "_" + goos + "_" + goarch + ".zip"
+	zipUrl := artifact.URL
 	// nolint:revive // FIXME: var-naming: var hashUrl should be hashURL (revive)
-	hashUrl := mirrorURL + "/" + versionPrefix + tfversion + "_SHA256SUMS"
+	hashUrl := artifact.ChecksumsURL
 	// nolint:revive // FIXME: var-naming: var hashSignatureUrl should be hashSignatureURL (revive)
-	hashSignatureUrl := mirrorURL + "/" + versionPrefix + tfversion + "_SHA256SUMS." + product.GetShaSignatureSuffix()
+	hashSignatureUrl := artifact.ChecksumsSignatureURL
+
 
 	match := false
 
-	pubKeyFilename, err := downloadPublicKey(product, installLocation, &wg)
-	if err != nil {
-		logger.Error("Could not download public PGP key file")
-		return "", err
+	var pubKeyFilename string
+	var err error
+	if isSigned {
+		pubKeyFilename, err = downloadPublicKey(product, installLocation, &wg)
+		if err != nil {
+			logger.Error("Could not download public PGP key file")
+			return "", err
+		}
 	}
 
 	logger.Infof("Downloading %q", zipUrl)
@@ -61,55 +86,67 @@ func DownloadProductFromURL(product Product, installLocation, mirrorURL, tfversi
 	}
 	defer os.Remove(hashFilePath)
 
-	logger.Infof("Downloading %q", hashSignatureUrl)
-	hashSigFilePath, err := downloadFromURL(installLocation, hashSignatureUrl, &wg)
+	if isSigned {
+		if err = verifyHashFileSignature(product, pubKeyFilename, hashFilePath, hashSignatureUrl, installLocation, &wg); err != nil {
+			return "", err
+		}
+	} else {
+		logger.Warnf("%s does not publish PGP signatures: verifying checksum only", product.GetName())
+	}
+
+	targetFile, err := os.Open(zipFilePath)
 	if err != nil {
-		logger.Error("Could not download hash signature file")
+		logger.Errorf("Could not open zip file %q: %v", zipFilePath, err)
 		return "", err
 	}
+	defer targetFile.Close()
+
+	match = checkChecksumMatches(hashFilePath, targetFile)
+	if !match {
+		return "", errors.New("Checksums did not match")
+	}
+
+	return zipFilePath, err
+}
+
+// verifyHashFileSignature : download signature of the checksum (hash) file and verify it
+func verifyHashFileSignature(product Product, pubKeyFilename, hashFilePath, hashSignatureURL, installLocation string, wg *sync.WaitGroup) error {
+	logger.Infof("Downloading %q", hashSignatureURL)
+	hashSigFilePath, err := downloadFromURL(installLocation, hashSignatureURL, wg)
+	if err != nil {
+		logger.Error("Could not download hash signature file")
+		return err
+	}
 	defer os.Remove(hashSigFilePath)
 
 	publicKeyFile, err := os.Open(pubKeyFilename)
 	if err != nil {
 		logger.Errorf("Could not open public key %q: %v", pubKeyFilename, err)
-		return "", err
+		return err
 	}
 	defer publicKeyFile.Close()
 
 	signatureFile, err := os.Open(hashSigFilePath)
 	if err != nil {
 		logger.Errorf("Could not open hash signature file %q: %v", hashSigFilePath, err)
-		return "", err
+		return err
 	}
 	defer signatureFile.Close()
 
-	targetFile, err := os.Open(zipFilePath)
-	if err != nil {
-		logger.Errorf("Could not open zip file %q: %v", zipFilePath, err)
-		return "", err
-	}
-	defer targetFile.Close()
-
 	hashFile, err := os.Open(hashFilePath)
 	if err != nil {
 		logger.Errorf("Could not open hash file %q: %v", hashFilePath, err)
-		return "", err
+		return err
 	}
 	defer hashFile.Close()
 
 	var verifySucceed bool
 	if verifySucceed, err = verifySignature(product, publicKeyFile, hashFile, signatureFile); err != nil {
-		return "", err
+		return err
 	} else if !verifySucceed {
-		return "", fmt.Errorf("Unable to verify checksum signature against PGP key")
-	}
-
-	match = checkChecksumMatches(hashFilePath, targetFile)
-	if !match {
-		return "", errors.New("Checksums did not match")
+		return fmt.Errorf("Unable to verify checksum signature against PGP key")
 	}
-
-	return zipFilePath, err
+	return nil
 }
 
 // verifySignature: Verify signature of checksum (hash) file
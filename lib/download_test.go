@@ -450,3 +450,66 @@ func TestDownloadProductFromURL_multiple_public_keys(t *testing.T) {
 		t.Errorf("Returned zipFile not expected path. Expected: %q, actual: %q", expectedZipPath, zipFilePath)
 	}
 }
+
+// TestDownloadProductFromURL_unsigned_binary : Test DownloadProductFromURL with product shipping
+// uncompressed executables and unsigned checksums (e.g. Terragrunt)
+func TestDownloadProductFromURL_unsigned_binary(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	executableBytes := []byte("This is the main executable")
+	sha256Hash := sha256.Sum256(executableBytes)
+	checksumFileContent := hex.EncodeToString(sha256Hash[:]) + "  " + "myprod_linux_amd64\n"
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/download/v2.1.0/myprod_linux_amd64":
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write(executableBytes); err != nil {
+				t.Error(err)
+			}
+		case "/releases/download/v2.1.0/SHA256SUMS":
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write([]byte(checksumFileContent)); err != nil {
+				t.Error(err)
+			}
+		default:
+			http.NotFoundHandler().ServeHTTP(w, r)
+		}
+	}))
+	defer mockServer.Close()
+
+	mockProduct := TerragruntProduct{
+		ProductDetails{
+			ID:                    "myproduct",
+			Name:                  "Mock Product",
+			DefaultDownloadMirror: mockServer.URL + "/releases/download",
+			VersionPrefix:         "myprod_",
+			ExecutableName:        "myprod",
+			ArchivePrefix:         "myprod_",
+			ArchiveFormat:         ArchiveFormatBinary,
+			ReleaseTagPrefix:      "v",
+			UnsignedReleases:      true,
+		},
+	}
+
+	tempDir := t.TempDir()
+	binaryFilePath, err := DownloadProductFromURL(mockProduct, tempDir, mockProduct.GetArtifactUrl("", "2.1.0"), "2.1.0", mockProduct.GetArchivePrefix(), "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedPath := filepath.Join(tempDir, "myprod_linux_amd64"); binaryFilePath != expectedPath {
+		t.Errorf("Returned binary file not expected path. Expected: %q, actual: %q", expectedPath, binaryFilePath)
+	}
+
+	// Checksum mismatch must still be detected without a signature
+	checksumFileContent = strings.Repeat("0", 64) + "  " + "myprod_linux_amd64\n"
+	if _, err = DownloadProductFromURL(mockProduct, tempDir, mockProduct.GetArtifactUrl("", "2.1.0"), "2.1.0", mockProduct.GetArchivePrefix(), "linux", "amd64"); err == nil {
+		t.Error("Expected checksum mismatch error, got nil")
+	}
+
+	// Signature must be verified, unless releases are unsigned explicitly
+	mockProduct.UnsignedReleases = false
+	checksumFileContent = hex.EncodeToString(sha256Hash[:]) + "  " + "myprod_linux_amd64\n"
+	if _, err = DownloadProductFromURL(mockProduct, tempDir, mockProduct.GetArtifactUrl("", "2.1.0"), "2.1.0", mockProduct.GetArchivePrefix(), "linux", "amd64"); err == nil {
+		t.Error("Expected error for product without public PGP key, got nil")
+	}
+}
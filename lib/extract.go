@@ -0,0 +1,230 @@
+//nolint:staticcheck //ST1005: error strings should not be capitalized (staticcheck)
+package lib
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ulikunitz/xz"
+)
+
+// Maximum size of the extracted executable.
+// Prevents the "G110: Potential DoS vulnerability via decompression bomb (gosec)"
+const maxExtractedFileSize = int64(1024 * 1024 * 1024) // 1 GB
+
+// Extractor : extracts the product executable from the downloaded release artifact
+type Extractor interface {
+	// Extract fileName from the src artifact into dest directory and return path to the extracted file
+	Extract(src string, dest string, fileName string) (string, error)
+}
+
+type zipExtractor struct{}
+
+type tarExtractor struct {
+	decompress func(io.Reader) (io.Reader, error)
+}
+
+type binaryExtractor struct{}
+
+// Known extensions of the artifacts per archive format.
+// Order matters: first matching extension wins.
+var archiveFormatExtensions = []struct {
+	format    string
+	extension string
+}{
+	{format: ArchiveFormatZip, extension: ".zip"},
+	{format: ArchiveFormatTarGz, extension: ".tar.gz"},
+	{format: ArchiveFormatTarGz, extension: ".tgz"},
+	{format: ArchiveFormatTarXz, extension: ".tar.xz"},
+	{format: ArchiveFormatTarXz, extension: ".txz"},
+}
+
+// archiveFormatExtension : file extension of the artifacts in the given archive format
+func archiveFormatExtension(format string) string {
+	for _, item := range archiveFormatExtensions {
+		if item.format == format {
+			return item.extension
+		}
+	}
+	return ""
+}
+
+// GetExtractor : get extractor for the archive format (one of ArchiveFormat* constants)
+func GetExtractor(format string) (Extractor, error) {
+	switch format {
+	case ArchiveFormatZip, "":
+		return zipExtractor{}, nil
+	case ArchiveFormatTarGz:
+		return tarExtractor{decompress: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }}, nil
+	case ArchiveFormatTarXz:
+		return tarExtractor{decompress: func(r io.Reader) (io.Reader, error) { return xz.NewReader(r) }}, nil
+	case ArchiveFormatBinary:
+		return binaryExtractor{}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported archive format: %q", format)
+	}
+}
+
+// getArtifactExtractor : get extractor by extension of the downloaded artifact,
+// falling back to the archive format of the product for unknown extensions
+func getArtifactExtractor(product Product, artifactPath string) (Extractor, error) {
+	for _, item := range archiveFormatExtensions {
+		if strings.HasSuffix(strings.ToLower(artifactPath), item.extension) {
+			logger.Debugf("Using %s extractor for %q", item.format, artifactPath)
+			return GetExtractor(item.format)
+		}
+	}
+	logger.Debugf("Using %s extractor of %s for %q", product.GetArchiveFormat(), product.GetName(), artifactPath)
+	return GetExtractor(product.GetArchiveFormat())
+}
+
+func (e zipExtractor) Extract(src string, dest string, fileName string) (string, error) {
+	filenames, err := Unzip(src, dest, fileName)
+	if err != nil {
+		return "", err
+	}
+	return filenames[0], nil
+}
+
+func (e tarExtractor) Extract(src string, dest string, fileName string) (string, error) {
+	logger.Debugf("Extracting tar archive %q", src)
+
+	archive, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	decompressed, err := e.decompress(archive)
+	if err != nil {
+		return "", fmt.Errorf("Could not decompress %q: %v", src, err)
+	}
+
+	destination, err := filepath.Abs(dest)
+	if err != nil {
+		return "", fmt.Errorf("Could not open destination: %v", err)
+	}
+
+	var extractedFile string
+	tarReader := tar.NewReader(decompressed)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("Could not read %q: %v", src, err)
+		}
+
+		// Only extract the main binary
+		// from the archive, ignoring LICENSE and other files
+		if header.Typeflag != tar.TypeReg || path.Clean(header.Name) != ConvertExecutableExt(fileName) {
+			continue
+		}
+		if extractedFile != "" {
+			return "", fmt.Errorf("Found more than one %s file in release archive", fileName)
+		}
+
+		extractedFile, err = extractFile(tarReader, destination, header.Name, header.FileInfo().Mode())
+		if err != nil {
+			return "", fmt.Errorf("Error extracting: %v", err)
+		}
+	}
+
+	if extractedFile == "" {
+		return "", fmt.Errorf("Could not find %s file in release archive to extract", fileName)
+	}
+	return extractedFile, nil
+}
+
+func (e binaryExtractor) Extract(src string, dest string, fileName string) (string, error) {
+	logger.Debugf("Copying executable %q", src)
+
+	source, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+
+	destination, err := filepath.Abs(dest)
+	if err != nil {
+		return "", fmt.Errorf("Could not open destination: %v", err)
+	}
+
+	filePath, err := safeJoin(destination, ConvertExecutableExt(fileName))
+	if err != nil {
+		return "", err
+	}
+	if absSrc, errAbs := filepath.Abs(src); errAbs == nil && absSrc == filePath {
+		logger.Debugf("Executable %q is already in place", filePath)
+		return filePath, os.Chmod(filePath, 0o755) // nolint:gosec // executable must be world-executable
+	}
+
+	return extractFile(source, destination, ConvertExecutableExt(fileName), 0o755)
+}
+
+// extractFile : write content of the archive member name into destination directory
+func extractFile(content io.Reader, destination string, name string, mode os.FileMode) (string, error) {
+	filePath, err := safeJoin(destination, name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	destinationFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return "", err
+	}
+	defer func(destinationFile *os.File) {
+		logger.Debugf("Closing destination file handler %q", destinationFile.Name())
+		_ = destinationFile.Close()
+	}(destinationFile)
+
+	logger.Debugf("Extracting file %q to %q", name, destinationFile.Name())
+	if err := copyWithSizeLimit(destinationFile, content, name); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// safeJoin : join archive member name to the destination directory,
+// making sure the result is not vulnerable to Zip Slip (path traversal)
+func safeJoin(destination string, name string) (string, error) {
+	filePath := filepath.Join(destination, name) // nolint:gosec // The "G305: File traversal when extracting zip/tar archive" is handled below
+	if !strings.HasPrefix(filePath, filepath.Clean(destination)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("Invalid file path: %q", filePath)
+	}
+	return filePath, nil
+}
+
+// copyWithSizeLimit : copy content of the archive member name, failing if it exceeds maxExtractedFileSize
+func copyWithSizeLimit(dst io.Writer, src io.Reader, name string) error {
+	totalCopied := int64(0)
+	for {
+		copied, err := io.CopyN(dst, src, 1024*1024)
+		totalCopied += copied
+		if totalCopied%(10*1024*1024) == 0 { // Print stats every 10 MB
+			logger.Debugf("Size copied so far: %3.d MB\r", totalCopied/1024/1024)
+		}
+		if err != nil {
+			if err == io.EOF {
+				logger.Debugf("Total size copied: %4.d MB\r", totalCopied/1024/1024)
+				break
+			}
+			return err
+		}
+		if totalCopied > maxExtractedFileSize {
+			return fmt.Errorf("file %q is too large (> %d MB)", name, maxExtractedFileSize/1024/1024)
+		}
+	}
+	return nil
+}
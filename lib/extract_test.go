@@ -0,0 +1,142 @@
+package lib
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+// writeTestTarArchive : write tar archive with the given members, compressed with compress
+func writeTestTarArchive(t *testing.T, archivePath string, members map[string]string, compress func(io.Writer) (io.WriteCloser, error)) {
+	t.Helper()
+	buffer := new(bytes.Buffer)
+	compressor, err := compress(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tarWriter := tar.NewWriter(compressor)
+	for name, content := range members {
+		header := &tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := compressor.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archivePath, buffer.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtract_tar(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	compressors := map[string]func(io.Writer) (io.WriteCloser, error){
+		ArchiveFormatTarGz: func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+		ArchiveFormatTarXz: func(w io.Writer) (io.WriteCloser, error) { return xz.NewWriter(w) },
+	}
+	for format, compress := range compressors {
+		tempDir := t.TempDir()
+		archivePath := filepath.Join(tempDir, "myprod_1.0.0_linux_amd64"+archiveFormatExtension(format))
+		writeTestTarArchive(t, archivePath, map[string]string{
+			"./" + ConvertExecutableExt("myprod"): "MyProdBinaryContent\n",
+			"LICENSE":                             "License\n",
+		}, compress)
+
+		extractor, err := getArtifactExtractor(GetProductById("terraform"), archivePath)
+		if err != nil {
+			t.Fatalf("Unexpected error getting %s extractor: %v", format, err)
+		}
+		extractedFile, err := extractor.Extract(archivePath, tempDir, "myprod")
+		if err != nil {
+			t.Fatalf("Unexpected error extracting %s archive: %v", format, err)
+		}
+		if expected := filepath.Join(tempDir, ConvertExecutableExt("myprod")); extractedFile != expected {
+			t.Errorf("Unexpected extracted file from %s archive. Expected: %q, actual: %q", format, expected, extractedFile)
+		}
+		if content, _ := os.ReadFile(extractedFile); string(content) != "MyProdBinaryContent\n" {
+			t.Errorf("Unexpected content extracted from %s archive: %q", format, content)
+		}
+		if checkFileExist(filepath.Join(tempDir, "LICENSE")) {
+			t.Errorf("LICENSE file should not be extracted from %s archive", format)
+		}
+
+		if _, err := extractor.Extract(archivePath, tempDir, "otherprod"); err == nil {
+			t.Errorf("Expected error extracting missing file from %s archive. Got nil", format)
+		}
+	}
+}
+
+func TestExtract_binary(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	tempDir := t.TempDir()
+	artifactPath := filepath.Join(tempDir, "myprod_linux_amd64")
+	if err := os.WriteFile(artifactPath, []byte("MyProdBinaryContent\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	extractor, err := GetExtractor(ArchiveFormatBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractedFile, err := extractor.Extract(artifactPath, tempDir, "myprod")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	info, err := os.Stat(extractedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Extracted file %q is not executable: %v", extractedFile, info.Mode())
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	destination := t.TempDir()
+	if _, err := safeJoin(destination, "terraform"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, name := range []string{"../terraform", "bin/../../terraform", "."} {
+		if _, err := safeJoin(destination, name); err == nil {
+			t.Errorf("Expected error joining %q. Got nil", name)
+		}
+	}
+}
+
+func TestCopyWithSizeLimit(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	if err := copyWithSizeLimit(io.Discard, strings.NewReader("content"), "small"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	tooLarge := io.LimitReader(zeroReader{}, maxExtractedFileSize+2*1024*1024)
+	if err := copyWithSizeLimit(io.Discard, tooLarge, "large"); err == nil {
+		t.Error("Expected error copying file larger than the size limit. Got nil")
+	}
+}
+
+func TestGetExtractor_unsupported(t *testing.T) {
+	if _, err := GetExtractor("rar"); err == nil {
+		t.Error("Expected error for unsupported archive format. Got nil")
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
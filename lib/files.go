@@ -262,9 +262,9 @@ func GetHomeDirectory() string {
 func unzipFile(f *zip.File, destination string, wg *sync.WaitGroup) error {
 	defer wg.Done()
 	// 1. Check if file paths are not vulnerable to Zip Slip
-	filePath := filepath.Join(destination, f.Name) // nolint:gosec // The "G305: File traversal when extracting zip/tar archive" is handled below
-	if !strings.HasPrefix(filePath, filepath.Clean(destination)+string(os.PathSeparator)) {
-		return fmt.Errorf("Invalid file path: %q", filePath)
+	filePath, err := safeJoin(destination, f.Name)
+	if err != nil {
+		return err
 	}
 
 	// 2. Create directory tree
@@ -276,50 +276,16 @@ func unzipFile(f *zip.File, destination string, wg *sync.WaitGroup) error {
 		return nil
 	}
 
-	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
-		return err
-	}
-
-	// 3. Create a destination file for unzipped content
-	destinationFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
-	defer func(destinationFile *os.File) {
-		logger.Debugf("Closing destination file handler %q", destinationFile.Name())
-		_ = destinationFile.Close()
-	}(destinationFile)
+	// 3. Unzip the content of a file and copy it to the destination file
+	zippedFile, err := f.Open()
 	if err != nil {
 		return err
 	}
-
-	// 4. Unzip the content of a file and copy it to the destination file
-	zippedFile, err := f.Open()
 	defer func(zippedFile io.ReadCloser) {
 		logger.Debugf("Closing zipped file handler %q", f.Name)
 		_ = zippedFile.Close()
 	}(zippedFile)
-	if err != nil {
-		return err
-	}
 
-	logger.Debugf("Extracting file %q to %q", f.Name, destinationFile.Name())
-	// Prevent the "G110: Potential DoS vulnerability via decompression bomb (gosec)"
-	totalCopied := int64(0)
-	maxSize := int64(1024 * 1024 * 1024) // 1 GB
-	for {
-		copied, err := io.CopyN(destinationFile, zippedFile, 1024*1024)
-		totalCopied += copied
-		if totalCopied%(10*1024*1024) == 0 { // Print stats every 10 MB
-			logger.Debugf("Size copied so far: %3.d MB\r", totalCopied/1024/1024)
-		}
-		if err != nil {
-			if err == io.EOF {
-				logger.Debugf("Total size copied: %4.d MB\r", totalCopied/1024/1024)
-				break
-			}
-			return err
-		}
-		if totalCopied > maxSize {
-			return fmt.Errorf("file %q is too large (> %d MB)", f.Name, maxSize/1024/1024)
-		}
-	}
-	return nil
+	_, err = extractFile(zippedFile, destination, f.Name, f.Mode())
+	return err
 }
@@ -0,0 +1,201 @@
+//nolint:staticcheck //ST1005: error strings should not be capitalized (staticcheck)
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Environment variables holding GitHub token, in order of precedence
+var gitHubTokenEnvVars = []string{"GITHUB_TOKEN", "GH_TOKEN"}
+
+// Host of GitHub API, which the token is always sent to
+const gitHubAPIHost = "api.github.com"
+
+// Safety net against endless pagination
+const gitHubReleasesMaxPages = 100
+
+// Matches next page URL in GitHub API Link header:
+// <https://api.github.com/repositories/1/releases?page=2>; rel="next", <...>; rel="last"
+var regexGitHubLinkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Struct representing a release from GitHub releases API:
+// https://docs.github.com/en/rest/releases/releases#list-releases
+type GitHubRelease struct {
+	TagName    string               `json:"tag_name"`
+	Name       string               `json:"name"`
+	Draft      bool                 `json:"draft"`
+	Prerelease bool                 `json:"prerelease"`
+	Assets     []GitHubReleaseAsset `json:"assets"`
+}
+
+type GitHubReleaseAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+}
+
+// Releases listed per URL, as the same list of versions is needed more than once per run
+// and unauthenticated requests to GitHub API are rate limited
+var (
+	gitHubReleasesCache   = map[string][]GitHubRelease{}
+	gitHubReleasesCacheMu sync.Mutex
+)
+
+// ListGitHubReleases : list all releases from GitHub releases API URL
+// (e.g. https://api.github.com/repos/gruntwork-io/terragrunt/releases), following pagination
+func ListGitHubReleases(releasesURL string) ([]GitHubRelease, error) {
+	gitHubReleasesCacheMu.Lock()
+	cachedReleases, ok := gitHubReleasesCache[releasesURL]
+	gitHubReleasesCacheMu.Unlock()
+	if ok {
+		logger.Debugf("Using cached list of releases from %q", releasesURL)
+		return slices.Clone(cachedReleases), nil
+	}
+
+	releases, err := listGitHubReleasesPages(releasesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	gitHubReleasesCacheMu.Lock()
+	gitHubReleasesCache[releasesURL] = releases
+	gitHubReleasesCacheMu.Unlock()
+	return slices.Clone(releases), nil
+}
+
+// listGitHubReleasesPages : request all pages of releases from GitHub releases API URL
+func listGitHubReleasesPages(releasesURL string) ([]GitHubRelease, error) {
+	pageURL, err := gitHubReleasesFirstPageURL(releasesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []GitHubRelease
+	for page := 1; pageURL != ""; page++ {
+		if page > gitHubReleasesMaxPages {
+			return nil, fmt.Errorf("Too many pages of releases at %q (> %d)", releasesURL, gitHubReleasesMaxPages)
+		}
+
+		logger.Debugf("Getting page %d of releases from %q", page, pageURL)
+		var pageReleases []GitHubRelease
+		pageURL, err = getGitHubReleasesPage(pageURL, releasesURL, &pageReleases)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, pageReleases...)
+	}
+
+	return releases, nil
+}
+
+// gitHubReleasesFirstPageURL : request maximum page size, unless set explicitly
+func gitHubReleasesFirstPageURL(releasesURL string) (string, error) {
+	parsedURL, err := url.Parse(releasesURL)
+	if err != nil {
+		return "", fmt.Errorf("Invalid GitHub releases URL %q: %v", releasesURL, err)
+	}
+	query := parsedURL.Query()
+	if !query.Has("per_page") {
+		query.Set("per_page", "100")
+		parsedURL.RawQuery = query.Encode()
+	}
+	return parsedURL.String(), nil
+}
+
+// getGitHubReleasesPage : decode single page of releases and return URL of the next page, if any.
+// GitHub token is only sent to the host of releasesURL (next page may be linked on any host) and GitHub API.
+func getGitHubReleasesPage(pageURL string, releasesURL string, releases *[]GitHubRelease) (string, error) {
+	request, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	request.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token, envVar := gitHubToken(); token != "" {
+		switch {
+		case request.URL.Scheme != "https":
+			logger.Warnf("Not sending token from %q env var over insecure connection to %q", envVar, request.URL.Host)
+		case !isGitHubTokenHost(request.URL.Host, releasesURL):
+			logger.Warnf("Not sending token from %q env var to %q, as releases are listed from another host", envVar, request.URL.Host)
+		default:
+			logger.Debugf("Authenticating to GitHub API with token from %q env var", envVar)
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	response, err := http.DefaultClient.Do(request) // nolint:gosec // `pageURL' is expected to be variable
+	if err != nil {
+		return "", fmt.Errorf("Error getting releases from %q: %v", pageURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		if response.Header.Get("X-RateLimit-Remaining") == "0" {
+			return "", fmt.Errorf("GitHub API rate limit exceeded at %q: set one of %s env vars to raise the limit",
+				pageURL, strings.Join(gitHubTokenEnvVars, ", "))
+		}
+		return "", fmt.Errorf("Error retrieving releases from %q: %s", pageURL, response.Status)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("Error reading releases from %q: %v", pageURL, err)
+	}
+	if err := json.Unmarshal(body, releases); err != nil {
+		return "", fmt.Errorf("Could not parse releases from %q: %v", pageURL, err)
+	}
+
+	nextPageURL := ""
+	if match := regexGitHubLinkNext.FindStringSubmatch(response.Header.Get("Link")); match != nil {
+		nextPageURL = match[1]
+	}
+	return nextPageURL, nil
+}
+
+// isGitHubTokenHost : whether GitHub token may be sent to the host: GitHub API or the host of releasesURL
+func isGitHubTokenHost(host string, releasesURL string) bool {
+	if strings.EqualFold(host, gitHubAPIHost) {
+		return true
+	}
+	parsedURL, err := url.Parse(releasesURL)
+	return err == nil && strings.EqualFold(host, parsedURL.Host)
+}
+
+// gitHubToken : get GitHub token and the name of env var it was read from
+func gitHubToken() (string, string) {
+	for _, envVar := range gitHubTokenEnvVars {
+		if token := strings.TrimSpace(os.Getenv(envVar)); token != "" {
+			return token, envVar
+		}
+	}
+	return "", ""
+}
+
+// getVersionsFromGitHubReleases : get versions from releases, stripping tagPrefix from the tag names.
+// Drafts and releases without assets are never installable, hence always skipped.
+// Releases flagged as prerelease are only included if preRelease is true.
+func getVersionsFromGitHubReleases(releases []GitHubRelease, tagPrefix string, preRelease bool) []string {
+	var versions []string
+	for _, release := range releases {
+		switch {
+		case release.Draft:
+			logger.Tracef("Skipping draft release %q", release.TagName)
+		case release.Prerelease && !preRelease:
+			logger.Tracef("Skipping prerelease %q", release.TagName)
+		case len(release.Assets) == 0:
+			logger.Debugf("Skipping release %q without assets", release.TagName)
+		default:
+			versions = append(versions, strings.TrimPrefix(release.TagName, tagPrefix))
+		}
+	}
+	return versions
+}
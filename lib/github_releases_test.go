@@ -0,0 +1,181 @@
+package lib
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// setupTestGitHubReleasesServer : serve 2 pages of releases, linked with Link header like GitHub does
+func setupTestGitHubReleasesServer(t *testing.T, authorizations *[]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+		if r.URL.Path != "/repos/acme/tool/releases" {
+			http.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+		if perPage := r.URL.Query().Get("per_page"); perPage != "100" {
+			t.Errorf("Expected per_page=100 query parameter, got %q", perPage)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/tool/releases?per_page=100&page=2>; rel="next", <%s/repos/acme/tool/releases?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+			_, _ = w.Write([]byte(`[
+				{"tag_name": "v1.3.0", "draft": true, "assets": [{"name": "tool_linux_amd64"}]},
+				{"tag_name": "v1.2.0-rc1", "prerelease": true, "assets": [{"name": "tool_linux_amd64"}]},
+				{"tag_name": "v1.1.1", "assets": []},
+				{"tag_name": "v1.1.0", "assets": [{"name": "tool_linux_amd64", "browser_download_url": "https://example.com/tool_linux_amd64", "size": 42}]}
+			]`))
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/tool/releases?per_page=100&page=1>; rel="prev", <%s/repos/acme/tool/releases?per_page=100&page=1>; rel="first"`, server.URL, server.URL))
+			_, _ = w.Write([]byte(`[{"tag_name": "v1.0.0", "assets": [{"name": "tool_linux_amd64"}]}]`))
+		default:
+			http.NotFoundHandler().ServeHTTP(w, r)
+		}
+	}))
+	return server
+}
+
+func TestListGitHubReleases(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "secret")
+	var authorizations []string
+	server := setupTestGitHubReleasesServer(t, &authorizations)
+	defer server.Close()
+
+	releases, err := ListGitHubReleases(server.URL + "/repos/acme/tool/releases")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(releases) != 5 {
+		t.Fatalf("Expected 5 releases from 2 pages, got %d", len(releases))
+	}
+	if asset := releases[3].Assets[0]; asset.Name != "tool_linux_amd64" || asset.BrowserDownloadURL != "https://example.com/tool_linux_amd64" || asset.Size != 42 {
+		t.Errorf("Unexpected asset: %+v", asset)
+	}
+	if !releases[0].Draft || !releases[1].Prerelease {
+		t.Errorf("Unexpected draft/prerelease flags: %+v, %+v", releases[0], releases[1])
+	}
+
+	// Token must not be sent over plain HTTP
+	if expected := []string{"", ""}; !slices.Equal(authorizations, expected) {
+		t.Errorf("Unexpected authorization headers. Expected: %q, actual: %q", expected, authorizations)
+	}
+
+	// Listed once per run
+	if releases, err = ListGitHubReleases(server.URL + "/repos/acme/tool/releases"); err != nil || len(releases) != 5 {
+		t.Fatalf("Expected 5 cached releases, got %d (error: %v)", len(releases), err)
+	}
+	if len(authorizations) != 2 {
+		t.Errorf("Expected releases to be requested once (2 pages), got %d requests", len(authorizations))
+	}
+}
+
+func TestListGitHubReleases_token_host(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	t.Setenv("GITHUB_TOKEN", "secret")
+	var otherHostAuthorization string
+	otherServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherHostAuthorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[{"tag_name": "v1.0.0", "assets": [{"name": "tool_linux_amd64"}]}]`))
+	}))
+	defer otherServer.Close()
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/releases?page=2>; rel="next"`, otherServer.URL))
+		_, _ = w.Write([]byte(`[{"tag_name": "v1.1.0", "assets": [{"name": "tool_linux_amd64"}]}]`))
+	}))
+	defer server.Close()
+
+	// Both servers use the same test certificate
+	defaultClient := http.DefaultClient
+	http.DefaultClient = server.Client()
+	t.Cleanup(func() {
+		http.DefaultClient = defaultClient
+	})
+
+	releases, err := ListGitHubReleases(server.URL + "/repos/acme/tool/releases")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("Expected 2 releases from 2 hosts, got %d", len(releases))
+	}
+	if expected := "Bearer secret"; authorization != expected {
+		t.Errorf("Expected token to be sent to releases host. Expected: %q, actual: %q", expected, authorization)
+	}
+	if otherHostAuthorization != "" {
+		t.Errorf("Expected no token to be sent to another host, got %q", otherHostAuthorization)
+	}
+}
+
+func TestGetVersionsFromGitHubReleases(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	var authorizations []string
+	server := setupTestGitHubReleasesServer(t, &authorizations)
+	defer server.Close()
+
+	product := TerragruntProduct{
+		ProductDetails{
+			ID:               "tool",
+			Name:             "Tool",
+			DefaultMirror:    server.URL + "/repos/acme/tool/releases",
+			VersionsSource:   VersionsSourceGitHubReleases,
+			ReleaseTagPrefix: "v",
+		},
+	}
+
+	versions, err := getTFList(product, product.GetDefaultMirrorUrl(), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"1.1.0", "1.0.0"}; !slices.Equal(versions, expected) {
+		t.Errorf("Unexpected stable versions. Expected: %q, actual: %q", expected, versions)
+	}
+
+	versions, err = getTFList(product, product.GetDefaultMirrorUrl(), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"1.2.0-rc1", "1.1.0", "1.0.0"}; !slices.Equal(versions, expected) {
+		t.Errorf("Unexpected versions including pre-releases. Expected: %q, actual: %q", expected, versions)
+	}
+}
+
+func TestListGitHubReleases_rate_limit(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := ListGitHubReleases(server.URL + "/repos/acme/tool/releases"); err == nil {
+		t.Error("Expected rate limit error. Got nil")
+	}
+}
+
+func TestGitHubToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	if token, _ := gitHubToken(); token != "" {
+		t.Errorf("Expected no token, got %q", token)
+	}
+
+	t.Setenv("GH_TOKEN", "gh")
+	if token, envVar := gitHubToken(); token != "gh" || envVar != "GH_TOKEN" {
+		t.Errorf("Expected token from GH_TOKEN, got %q from %q", token, envVar)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "github")
+	if token, envVar := gitHubToken(); token != "github" || envVar != "GITHUB_TOKEN" {
+		t.Errorf("Expected token from GITHUB_TOKEN, got %q from %q", token, envVar)
+	}
+}
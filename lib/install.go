@@ -47,6 +47,10 @@ func initialize(binPath string) {
 // GetInstallLocation : get location where the terraform binary will be installed,
 // will create the installDir if it does not exist
 func GetInstallLocation(installPath string) string {
+	if installPath == "" {
+		logger.Fatalf("Could not determine install path: home directory cannot be resolved. Set %s or use `--install` option", TFSwitchHomeEnvVar)
+	}
+
 	/* set installation location */
 	installLocation = filepath.Join(installPath, InstallDir)
 
@@ -89,17 +93,16 @@ func install(product Product, dryRun, showRequiredFlag bool, tfversion, binPath,
 
 	goos := runtime.GOOS
 
-	// Terraform darwin arm64 comes with 1.0.2 and next version
-	tfver, tfverErr := version.NewVersion(tfversion)
-	if tfverErr != nil {
-		return fmt.Errorf("Error parsing %q version: %v", tfversion, tfverErr)
-	}
-	tf102, tf102Err := version.NewVersion(tfDarwinArm64StartVersion)
-	if tf102Err != nil {
-		return fmt.Errorf("Error parsing %q version: %v", tfDarwinArm64StartVersion, tf102Err)
+	// Fail early if the version was not built for the platform, rather than on download
+	goarch, err := ResolvePlatformArch(product, tfversion, goos, goarch)
+	if err != nil {
+		return err
 	}
-	if goos == "darwin" && goarch == "arm64" && tfver.LessThan(tf102) {
-		goarch = "amd64"
+
+	// Prefer exactly the published artifact over the one guessed from the product naming conventions
+	artifact, err := GetReleaseArtifact(product, mirrorURL, mirrorDownloadURL, tfversion, goos, goarch)
+	if err != nil {
+		return err
 	}
 
 	logArgs := fmt.Sprintf("%s version %q for %q", product.GetName(), tfversion, goos+"_"+goarch)
@@ -141,7 +144,14 @@ func install(product Product, dryRun, showRequiredFlag bool, tfversion, binPath,
 	defer releaseLock(lockFile, lockedFH)
 
 	// If selected version doesn't already exist, proceed to download it
-	zipFile, errDownload := DownloadProductFromURL(product, installLocation, product.GetArtifactUrl(mirrorDownloadURL, tfversion), tfversion, product.GetArchivePrefix(), goos, goarch)
+	var zipFile string
+	var errDownload error
+	downloadLocation := getDownloadLocation(installLocation)
+	if artifact != nil {
+		zipFile, errDownload = DownloadReleaseArtifact(product, downloadLocation, *artifact)
+	} else {
+		zipFile, errDownload = DownloadProductFromURL(product, downloadLocation, product.GetArtifactUrl(mirrorDownloadURL, tfversion), tfversion, product.GetArchivePrefix(), goos, goarch)
+	}
 
 	/* If unable to download file from url, exit(1) immediately */
 	if errDownload != nil {
@@ -151,18 +161,25 @@ func install(product Product, dryRun, showRequiredFlag bool, tfversion, binPath,
 		logger.Fatalf("Error downloading: %s", errDownload)
 	}
 
-	/* unzip the downloaded zipfile */
-	_, errUnzip := Unzip(zipFile, installLocation, product.GetExecutableName())
-	if errUnzip != nil {
+	/* extract the executable from the downloaded artifact */
+	extractor, errExtractor := getArtifactExtractor(product, zipFile)
+	if errExtractor != nil {
+		// logger.Fatal doesn't invoke deferred functions,
+		// so need to release the lock explicitly
+		releaseLock(lockFile, lockedFH)
+		logger.Fatal(errExtractor)
+	}
+	_, errExtract := extractor.Extract(zipFile, installLocation, product.GetExecutableName())
+	if errExtract != nil {
 		// logger.Fatal doesn't invoke deferred functions,
 		// so need to release the lock explicitly
 		releaseLock(lockFile, lockedFH)
-		logger.Fatalf("Unable to unzip %q file: %v", zipFile, errUnzip)
+		logger.Fatalf("Unable to extract %q file: %v", zipFile, errExtract)
 	}
 
 	logger.Debug("Waiting for deferred functions")
 	wg.Wait()
-	/* rename unzipped file to terraform version name - terraform_x.x.x */
+	/* rename extracted file to terraform version name - terraform_x.x.x */
 	installFilePath := ConvertExecutableExt(filepath.Join(installLocation, product.GetExecutableName()))
 	RenameFile(installFilePath, installFileVersionPath)
 
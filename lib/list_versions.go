@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-version"
 )
@@ -36,6 +37,12 @@ func getVersionsFromJSON(product Product, body string, preRelease bool, tfVersio
 	if err != nil {
 		return err
 	}
+	return filterVersions(versionList, preRelease, tfVersionList)
+}
+
+// filterVersions : keep valid semantic versions (pre-releases only if preRelease is true)
+// and store them in tfVersionList in descending order
+func filterVersions(versionList []string, preRelease bool, tfVersionList *tfVersionList) error {
 	var semver string
 	if preRelease {
 		semver = regexSemVer.Full.String()
@@ -100,6 +107,10 @@ func getVersionsFromBody(body string, preRelease bool, tfVersionList *tfVersionL
 // getTFList : Get the list of available versions given the mirror URL
 func getTFList(product Product, mirrorURL string, preRelease bool) ([]string, error) {
 	logger.Debug("Getting list of versions")
+	if product.GetVersionsSource() == VersionsSourceGitHubReleases {
+		return getTFListFromGitHubReleases(product, mirrorURL, preRelease)
+	}
+
 	body, err := getTFURLBody(mirrorURL)
 	if err != nil {
 		return nil, err
@@ -121,6 +132,25 @@ func getTFList(product Product, mirrorURL string, preRelease bool) ([]string, er
 	return tfVerList.tflist, nil
 }
 
+// getTFListFromGitHubReleases : Get the list of available versions given the GitHub releases API URL
+func getTFListFromGitHubReleases(product Product, releasesURL string, preRelease bool) ([]string, error) {
+	releases, err := ListGitHubReleases(releasesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var tfVerList tfVersionList
+	versionList := getVersionsFromGitHubReleases(releases, product.GetReleaseTagPrefix(), preRelease)
+	if err := filterVersions(versionList, preRelease, &tfVerList); err != nil {
+		return nil, err
+	}
+
+	if len(tfVerList.tflist) == 0 {
+		logger.Errorf("Cannot get version list from GitHub releases: %s", releasesURL)
+	}
+	return tfVerList.tflist, nil
+}
+
 // getTFLatest : Get the latest version given the mirror URL
 func getTFLatest(product Product, mirrorURL string) (string, error) {
 	versions, err := getTFList(product, mirrorURL, false)
@@ -135,6 +165,41 @@ func getTFLatest(product Product, mirrorURL string) (string, error) {
 	return versions[0], nil
 }
 
+// GetLatestVersion : Get the latest stable version given the mirror URL
+func GetLatestVersion(product Product, mirrorURL string) (string, error) {
+	return getTFLatest(product, mirrorURL)
+}
+
+// GetLatestVersionMatching : Get the latest version (including pre-releases) matching the regular expression
+func GetLatestVersionMatching(product Product, mirrorURL string, pattern *regexp.Regexp) (string, error) {
+	tflist, errTFList := getTFList(product, mirrorURL, true)
+	if errTFList != nil {
+		return "", fmt.Errorf("Error getting list of versions from %q: %v", mirrorURL, errTFList)
+	}
+	return latestVersionMatching(tflist, pattern)
+}
+
+// latestVersionMatching : Get the highest version of the list matching the regular expression
+func latestVersionMatching(tflist []string, pattern *regexp.Regexp) (string, error) {
+	var latest *version.Version
+	for _, versionItem := range tflist {
+		if !pattern.MatchString(versionItem) {
+			continue
+		}
+		parsedVersion, err := version.NewVersion(versionItem)
+		if err != nil {
+			continue
+		}
+		if latest == nil || parsedVersion.GreaterThan(latest) {
+			latest = parsedVersion
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("Did not find version matching regular expression: %q", pattern.String())
+	}
+	return latest.Original(), nil
+}
+
 // getTFLatestImplicit : Get the latest implicit version given the mirror URL
 func getTFLatestImplicit(product Product, mirrorURL string, preRelease bool, requestedVersion string) (string, error) {
 	tflist, errTFList := getTFList(product, mirrorURL, preRelease) // get list of versions
@@ -166,11 +231,28 @@ func getTFLatestImplicit(product Product, mirrorURL string, preRelease bool, req
 	return semv, nil
 }
 
+// Responses of the mirrors, as the same list of versions is needed more than once per run.
+// The mutex guards the map only, so that requests to different mirrors don't wait for each other.
+var (
+	tfURLBodyCache   = map[string]string{}
+	tfURLBodyCacheMu sync.Mutex
+)
+
 // getTFURLBody : Get list of versions from the mirror URL
 func getTFURLBody(mirrorURL string) (string, error) {
+	tfURLBodyCacheMu.Lock()
+	cachedBody, ok := tfURLBodyCache[mirrorURL]
+	tfURLBodyCacheMu.Unlock()
+	if ok {
+		logger.Debugf("Using cached list of versions from %q", mirrorURL)
+		return cachedBody, nil
+	}
+	originalMirrorURL := mirrorURL
+
 	hasSlash := strings.HasSuffix(mirrorURL, "/")
 	isJSON := strings.HasSuffix(mirrorURL, ".json")
-	if !hasSlash && !isJSON {
+	hasQuery := strings.Contains(mirrorURL, "?")
+	if !hasSlash && !isJSON && !hasQuery {
 		// if it does not have slash - append slash
 		mirrorURL = fmt.Sprintf("%s/", mirrorURL)
 	}
@@ -190,6 +272,9 @@ func getTFURLBody(mirrorURL string) (string, error) {
 	}
 
 	bodyString := string(body)
+	tfURLBodyCacheMu.Lock()
+	tfURLBodyCache[originalMirrorURL] = bodyString
+	tfURLBodyCacheMu.Unlock()
 
 	return bodyString, nil
 }
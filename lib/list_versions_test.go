@@ -5,9 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"regexp"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -272,6 +274,57 @@ func TestGetTFLatest(t *testing.T) {
 	}
 }
 
+// TestGetLatestVersionMatching : Test GetLatestVersionMatching
+func TestGetLatestVersionMatching(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	server := getMockListVersionServer(MockListVersionServerConfig{EnableHashicorpJSON: true})
+	defer server.Close()
+	mirrorURL := server.URL + "/terraform/index.json"
+
+	for pattern, expected := range map[string]string{
+		`^0\.11`:  "0.11.13",
+		`^0\.12`:  "0.12.3-beta1",
+		`-rc\d+$`: "0.12.0-rc1",
+		`^1\.`:    "",
+	} {
+		version, err := GetLatestVersionMatching(GetProductById("terraform"), mirrorURL, regexp.MustCompile(pattern))
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Expected error for %q regular expression, got version %q", pattern, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		}
+		if version != expected {
+			t.Errorf("Expected latest version matching %q does not match. Expected: %s, actual: %s", pattern, expected, version)
+		}
+	}
+}
+
+// TestGetMinSemver : Test GetMinSemver
+func TestGetMinSemver(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	server := getMockListVersionServer(MockListVersionServerConfig{EnableHashicorpJSON: true})
+	defer server.Close()
+	mirrorURL := server.URL + "/terraform/index.json"
+
+	for constraint, expected := range map[string]string{
+		">= 0.12.0":         "0.12.0",
+		">= 0.11.0, < 0.13": "0.11.13",
+		"~> 0.12.1":         "0.12.1",
+	} {
+		version, err := GetMinSemver(GetProductById("terraform"), constraint, mirrorURL)
+		if err != nil {
+			t.Error(err)
+		}
+		if version != expected {
+			t.Errorf("Expected minimum version for %q does not match. Expected: %s, actual: %s", constraint, expected, version)
+		}
+	}
+}
+
 // TestGetTFLatestImplicit : Test getTFLatestImplicit
 func TestGetTFLatestImplicit(t *testing.T) {
 	logger = InitLogger("DEBUG")
@@ -375,6 +428,39 @@ func TestGetTFURLBody(t *testing.T) {
 	}
 }
 
+// TestGetTFURLBody_concurrent : requests to different mirrors don't wait for each other
+func TestGetTFURLBody_concurrent(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	release := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		_, _ = w.Write([]byte("slow"))
+	}))
+	defer slowServer.Close()
+	defer close(release)
+	fastServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("fast"))
+	}))
+	defer fastServer.Close()
+
+	go func() {
+		_, _ = getTFURLBody(slowServer.URL + "/index.json")
+	}()
+	time.Sleep(100 * time.Millisecond) // Let the slow request start
+
+	done := make(chan string)
+	go func() {
+		body, _ := getTFURLBody(fastServer.URL + "/index.json")
+		done <- body
+	}()
+	select {
+	case body := <-done:
+		assert.Equal(t, "fast", body)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Request to a mirror waited for the request to another mirror")
+	}
+}
+
 // TestRemoveDuplicateVersions :  test to removed duplicate
 func TestRemoveDuplicateVersions(t *testing.T) {
 	logger = InitLogger("DEBUG")
@@ -52,11 +52,21 @@ var loggingLevels = struct {
 	TRACE: slog.Levels{slog.PanicLevel, slog.FatalLevel, slog.ErrorLevel, slog.WarnLevel, slog.InfoLevel, slog.NoticeLevel, slog.DebugLevel, slog.TraceLevel},
 }
 
+// isEnvFlagSet : whether the environment variable is set to any but a false boolean value (e.g. `NO_COLOR=false`)
+func isEnvFlagSet(name string) bool {
+	value := os.Getenv(name)
+	if value == "" {
+		return false
+	}
+	isSet, err := ParseBool(value)
+	return isSet || err != nil
+}
+
 func isColorLogging() bool {
-	if os.Getenv("NO_COLOR") != "" {
+	if isEnvFlagSet("NO_COLOR") {
 		return false
 	} else if color.SupportColor() {
-		if os.Getenv("FORCE_COLOR") == "" {
+		if !isEnvFlagSet("FORCE_COLOR") {
 			return term.IsTerminal(int(os.Stdout.Fd())) //nolint:gosec // G115: file descriptor from os.Stdout is always a valid small integer
 		}
 		return true
@@ -100,6 +110,9 @@ func InitLogger(logLevel string) *slog.Logger {
 	fallbackLogLevel := "INFO"
 	logLevel = strings.ToUpper(logLevel)
 
+	// `gookit/color` disables color rendering on any non-empty NO_COLOR, including `NO_COLOR=false`
+	color.Enable = !isEnvFlagSet("NO_COLOR")
+
 	formatter := slog.NewTextFormatter()
 	formatter.ColorTheme = slog.ColorTheme
 	formatter.EnableColor = isColorLogging()
@@ -0,0 +1,156 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+const (
+	moduleBlockType   = "module"
+	moduleSourceAttr  = "source"
+	modulesDirName    = ".terraform"
+	modulesManifest   = "modules.json"
+	modulesSubdirName = "modules"
+)
+
+// Manifest of the modules installed by `terraform init` (or `tofu init`)
+type modulesManifestJSON struct {
+	Modules []struct {
+		Key    string `json:"Key"`
+		Source string `json:"Source"`
+		Dir    string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// getConstraintFromModuleTree : version constraints of the root module at relPath and its child modules,
+// as the version has to satisfy constraints of every module in the tree
+func getConstraintFromModuleTree(params Params, relPath string) ([]VersionSource, error) {
+	constraints, err := getConstraintFromModuleDir(params, relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, moduleDir := range getChildModuleDirs(params, relPath) {
+		moduleConstraints, err := getConstraintFromModuleDir(params, moduleDir)
+		if err != nil {
+			logger.Warnf("Skipping version constraint of child module at %q: %v", moduleDir, err)
+			continue
+		}
+		if len(moduleConstraints) > 0 {
+			logger.Debugf("Found version constraint in child module at %q: %q", moduleDir, joinVersionConstraints(moduleConstraints))
+			constraints = append(constraints, moduleConstraints...)
+		}
+	}
+	return constraints, nil
+}
+
+// getChildModuleDirs : directories of the child modules of the root module at rootDir: installed modules
+// listed in the modules manifest and local modules referenced by `module` blocks (recursively)
+func getChildModuleDirs(params Params, rootDir string) []string {
+	rootAbsDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil
+	}
+	visited := []string{rootAbsDir}
+	var moduleDirs []string
+	addModuleDir := func(dir string) bool {
+		absDir, err := filepath.Abs(dir)
+		if err != nil || slices.Contains(visited, absDir) || !lib.CheckDirExist(absDir) {
+			return false
+		}
+		visited = append(visited, absDir)
+		moduleDirs = append(moduleDirs, dir)
+		return true
+	}
+
+	for _, dir := range getInstalledModuleDirs(rootDir) {
+		addModuleDir(dir)
+	}
+
+	// Local modules may be referenced before `init`, and may reference other local modules
+	queue := []string{rootDir}
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		for _, source := range getLocalModuleSources(params, dir) {
+			if moduleDir := filepath.Join(dir, source); addModuleDir(moduleDir) {
+				queue = append(queue, moduleDir)
+			}
+		}
+	}
+
+	return moduleDirs
+}
+
+// getInstalledModuleDirs : directories of the modules listed in the manifest written by `init`
+func getInstalledModuleDirs(rootDir string) []string {
+	manifestPath := filepath.Join(rootDir, modulesDirName, modulesSubdirName, modulesManifest)
+	if !lib.IsRegularFile(manifestPath) {
+		logger.Tracef("No modules manifest at %q", manifestPath)
+		return nil
+	}
+
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		logger.Warnf("Could not read modules manifest %q: %v", manifestPath, err)
+		return nil
+	}
+	var manifest modulesManifestJSON
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		logger.Warnf("Could not parse modules manifest %q: %v", manifestPath, err)
+		return nil
+	}
+
+	var moduleDirs []string
+	for _, module := range manifest.Modules {
+		if module.Key == "" || module.Dir == "" { // Root module
+			continue
+		}
+		logger.Debugf("Found module %q (%s) in %q", module.Key, module.Source, manifestPath)
+		moduleDirs = append(moduleDirs, filepath.Join(rootDir, filepath.FromSlash(module.Dir)))
+	}
+	return moduleDirs
+}
+
+// getLocalModuleSources : sources of the `module` blocks of the module at dir referring to local directories
+func getLocalModuleSources(params Params, dir string) []string {
+	var sources []string
+	files, _, _ := getModuleFiles(params, dir)
+	for _, filePath := range files {
+		hclFile, diagnostics := parseConfigFile(hclparse.NewParser(), filePath)
+		if diagnostics.HasErrors() {
+			continue
+		}
+		content, _, _ := hclFile.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: moduleBlockType, LabelNames: []string{"name"}}},
+		})
+		for _, block := range content.Blocks {
+			blockContent, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: moduleSourceAttr}},
+			})
+			attr, found := blockContent.Attributes[moduleSourceAttr]
+			if !found {
+				continue
+			}
+			value, valueDiags := attr.Expr.Value(nil)
+			if valueDiags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.Type().Equals(cty.String) {
+				continue
+			}
+			// Only local paths, remote modules are found in the modules manifest after `init`
+			if source := value.AsString(); strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+				sources = append(sources, filepath.FromSlash(source))
+			}
+		}
+	}
+	return sources
+}
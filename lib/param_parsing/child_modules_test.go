@@ -0,0 +1,66 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestGetConstraintFromVersionsTF_child_modules(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	installedDir := filepath.Join(moduleDir, modulesDirName, modulesSubdirName, "vpc")
+	localDir := filepath.Join(repoDir, "modules", "network")
+	nestedDir := filepath.Join(repoDir, "modules", "subnet")
+	for _, dir := range []string{installedDir, localDir, nestedDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeTestFile(t, moduleDir, "main.tf", `
+terraform {
+  required_version = ">= 1.3.0"
+}
+module "network" {
+  source = "../../modules/network"
+}
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+`)
+	writeTestFile(t, filepath.Join(moduleDir, modulesDirName, modulesSubdirName), modulesManifest,
+		`{"Modules":[{"Key":"","Source":"","Dir":"."},{"Key":"vpc","Source":"registry.terraform.io/terraform-aws-modules/vpc/aws","Version":"5.0.0","Dir":".terraform/modules/vpc"}]}`)
+	writeTestFile(t, installedDir, "versions.tf", `terraform { required_version = ">= 1.0" }`)
+	writeTestFile(t, localDir, "main.tf", `
+terraform {
+  required_version = "< 1.6.0"
+}
+module "subnet" {
+  source = "../subnet"
+}
+`)
+	writeTestFile(t, nestedDir, "versions.tf", `terraform { required_version = ">= 1.3.0" }`)
+
+	params, err := getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: "terraform"})
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := ">= 1.3.0, >= 1.0, < 1.6.0"; params.VersionRequirement != expected {
+		t.Errorf("Unexpected combined version constraint. Expected: %q, Actual: %q", expected, params.VersionRequirement)
+	}
+
+	// Constraints of child modules apply even if the root module has none
+	writeTestFile(t, moduleDir, "main.tf", `module "network" { source = "../../modules/network" }`)
+	params, err = getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: "terraform"})
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := ">= 1.0, < 1.6.0, >= 1.3.0"; params.VersionRequirement != expected {
+		t.Errorf("Unexpected combined version constraint. Expected: %q, Actual: %q", expected, params.VersionRequirement)
+	}
+}
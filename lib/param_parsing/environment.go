@@ -4,6 +4,8 @@ package param_parsing
 import (
 	"os"
 	"reflect"
+
+	"github.com/warrensbox/terraform-switcher/lib"
 )
 
 func GetParamsFromEnvironment(params Params) Params {
@@ -16,8 +18,7 @@ func GetParamsFromEnvironment(params Params) Params {
 		toml := envVar.toml
 
 		if len(env) == 0 {
-			logger.Errorf("Internal error: environment variable name is empty for parameter %q mapping, skipping assignment", param)
-			continue
+			continue // Not settable from the environment
 		}
 		if len(param) == 0 {
 			logger.Errorf("Internal error: parameter name is empty for environment variable %q mapping, skipping assignment", env)
@@ -45,9 +46,7 @@ func GetParamsFromEnvironment(params Params) Params {
 		case reflect.String:
 			paramKey.SetString(envVarValue)
 		case reflect.Bool:
-			// Inherit `gookit/color` lib's behavior: whatever the value is, set it to true
-			// E.g. NO_COLOR: https://github.com/gookit/color/blob/master/color.go#L49
-			paramKey.SetBool(true)
+			paramKey.SetBool(parseEnvBool(env, envVarValue))
 		default:
 			logger.Errorf(
 				"Internal error: unhandled switch case for \"%T\" type of %q parameter (env var %q)",
@@ -58,3 +57,14 @@ func GetParamsFromEnvironment(params Params) Params {
 	}
 	return params
 }
+
+// parseEnvBool : boolean value of the environment variable.
+// Any value but a false one (e.g. `false`, `0`, `no`) means true, as per NO_COLOR convention: https://no-color.org
+func parseEnvBool(env, value string) bool {
+	boolValue, err := lib.ParseBool(value)
+	if err != nil {
+		logger.Warnf("Environment variable %q value %q is not a boolean, treating it as true", env, value)
+		return true
+	}
+	return boolValue
+}
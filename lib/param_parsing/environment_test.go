@@ -179,3 +179,68 @@ func TestNoAndForceColorEnvVars(t *testing.T) {
 		t.Logf("Success: %q", expectedOutput)
 	}
 }
+
+func TestGetParamsFromEnvironment_bool_from_env(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	for value, expected := range map[string]bool{
+		"true":  true,
+		"1":     true,
+		"yes":   true,
+		"other": true, // Any value but a false one, as per NO_COLOR convention
+		"false": false,
+		"0":     false,
+		"No":    false,
+		"off":   false,
+	} {
+		t.Setenv("NO_COLOR", value)
+		t.Setenv("TF_DRY_RUN", value)
+		params := initParams(Params{})
+		params = GetParamsFromEnvironment(params)
+		if params.NoColor != expected {
+			t.Errorf("Determined no color is not matching for %q. Got %t, expected %t", value, params.NoColor, expected)
+		}
+		if params.DryRun != expected {
+			t.Errorf("Determined dry run is not matching for %q. Got %t, expected %t", value, params.DryRun, expected)
+		}
+	}
+}
+
+func TestGetParamsFromEnvironment_all_options_from_env(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	expected := map[string]string{
+		"TF_CHDIR":                     "/chdir_from_env",
+		"TF_LATEST_PRE":                "1.10",
+		"TF_LATEST_STABLE":             "1.9",
+		"TF_MATCH_VERSION_REQUIREMENT": "1.9.5",
+		"TF_MIRROR":                    "https://mirror.example.com/terraform/index.json",
+		"TF_MIRROR_DOWNLOAD":           "https://mirror.example.com/terraform",
+		"TF_SHOW_LATEST_PRE":           "1.8",
+		"TF_SHOW_LATEST_STABLE":        "1.7",
+	}
+	for env, value := range expected {
+		t.Setenv(env, value)
+	}
+	for _, env := range []string{"TF_LATEST", "TF_LIST_ALL", "TF_SHOW_LATEST", "TF_SHOW_REQUIRED", "TF_STRICT_CONFIG"} {
+		t.Setenv(env, "true")
+	}
+
+	params := initParams(Params{})
+	params = GetParamsFromEnvironment(params)
+	for env, actual := range map[string]string{
+		"TF_CHDIR":                     params.ChDirPath,
+		"TF_LATEST_PRE":                params.LatestPre,
+		"TF_LATEST_STABLE":             params.LatestStable,
+		"TF_MATCH_VERSION_REQUIREMENT": params.MatchVersionRequirement,
+		"TF_MIRROR":                    params.MirrorURL,
+		"TF_MIRROR_DOWNLOAD":           params.MirrorDownloadURL,
+		"TF_SHOW_LATEST_PRE":           params.ShowLatestPre,
+		"TF_SHOW_LATEST_STABLE":        params.ShowLatestStable,
+	} {
+		if actual != expected[env] {
+			t.Errorf("Parameter from %q is not matching. Got %q, expected %q", env, actual, expected[env])
+		}
+	}
+	if !params.LatestFlag || !params.ListAllFlag || !params.ShowLatestFlag || !params.ShowRequiredFlag || !params.StrictConfig {
+		t.Errorf("Boolean parameters from environment are not all set: %+v", params)
+	}
+}
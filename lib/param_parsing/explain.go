@@ -0,0 +1,242 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pborman/getopt"
+)
+
+const (
+	ExplainFormatTable = "table"
+	ExplainFormatJSON  = "json"
+
+	sourceDefault         = "default"
+	sourceCommandLineArg  = "command line argument"
+	sourceDetectedProduct = "detected from working directory"
+)
+
+// Parameters which control the run itself rather than what is switched to
+var unexplainedParams = []string{"ExplainFormat", "HelpFlag", "VersionFlag"}
+
+// ParamSource : value of a parameter and where it came from
+type ParamSource struct {
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+}
+
+// ParamProvenance : final value of a parameter, its source and the values it overrode
+type ParamProvenance struct {
+	Param string `json:"parameter"`
+	ParamSource
+	Overridden []ParamSource `json:"overridden,omitempty"`
+}
+
+// Provenance : history of the parameter values, recorded as configuration layers are applied
+type Provenance struct {
+	last    Params
+	history map[string][]ParamSource
+}
+
+// explainedParamNames : names of the Params fields to report, in the order of declaration
+func explainedParamNames() []string {
+	var names []string
+	paramsType := reflect.TypeOf(Params{})
+	for i := range paramsType.NumField() {
+		field := paramsType.Field(i)
+		if kind := field.Type.Kind(); kind != reflect.String && kind != reflect.Bool {
+			continue
+		}
+		if !slices.Contains(unexplainedParams, field.Name) {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// newProvenance : start recording provenance from the default values of the parameters
+func newProvenance(params Params) *Provenance {
+	provenance := &Provenance{last: params, history: map[string][]ParamSource{}}
+	reflectedParams := reflect.ValueOf(params)
+	for _, name := range explainedParamNames() {
+		provenance.history[name] = []ParamSource{{Value: reflectedParams.FieldByName(name).Interface(), Source: sourceDefault}}
+	}
+	return provenance
+}
+
+// record : record parameters changed since the previous record, attributing them to the source returned for each parameter
+func (p *Provenance) record(params Params, source func(param string) string) {
+	if p == nil {
+		return
+	}
+	current := reflect.ValueOf(params)
+	previous := reflect.ValueOf(p.last)
+	for _, name := range explainedParamNames() {
+		value := current.FieldByName(name).Interface()
+		if value != previous.FieldByName(name).Interface() {
+			p.history[name] = append(p.history[name], ParamSource{Value: value, Source: source(name)})
+		}
+	}
+	p.last = params
+}
+
+// fork : copy of the provenance continuing from the parameters, e.g. when resolving them for another product
+func (p *Provenance) fork(params Params) *Provenance {
+	if p == nil {
+		return nil
+	}
+	history := make(map[string][]ParamSource, len(p.history))
+	for name, sources := range p.history {
+		history[name] = slices.Clone(sources)
+	}
+	return &Provenance{last: params, history: history}
+}
+
+// Params : provenance of each of the parameters
+func (p *Provenance) Params() []ParamProvenance {
+	if p == nil {
+		return nil
+	}
+	var provenance []ParamProvenance
+	for _, name := range explainedParamNames() {
+		sources := p.history[name]
+		if len(sources) == 0 {
+			continue
+		}
+		last := len(sources) - 1
+		// Flags are applied before and after the configuration files, so disregard the earlier application
+		overridden := slices.DeleteFunc(slices.Clone(sources[:last]), func(source ParamSource) bool {
+			return source == sources[last]
+		})
+		slices.Reverse(overridden) // Most recently overridden first
+		provenance = append(provenance, ParamProvenance{Param: name, ParamSource: sources[last], Overridden: overridden})
+	}
+	return provenance
+}
+
+// fixedSource : the same source for all parameters, e.g. a version file
+func fixedSource(source string) func(string) string {
+	return func(string) string {
+		return source
+	}
+}
+
+// versionSourcesSource : files recorded in VersionSources since the given number of sources
+// (e.g. the `versions.tf` declaring the constraint), or the fallback if none
+func versionSourcesSource(params Params, since int, fallback string) func(string) string {
+	var files []string
+	for _, versionSource := range params.VersionSources[min(since, len(params.VersionSources)):] {
+		if !slices.Contains(files, versionSource.Source) {
+			files = append(files, versionSource.Source)
+		}
+	}
+	if len(files) == 0 {
+		return fixedSource(fallback)
+	}
+	return fixedSource(strings.Join(files, ", "))
+}
+
+// envSource : environment variable mapped to the parameter
+func envSource(param string) string {
+	if mapping := getParamMapping(param); mapping != nil && mapping.env != "" {
+		return fmt.Sprintf("environment variable %s", mapping.env)
+	}
+	return "environment"
+}
+
+// tomlSource : key of the TOML configuration file mapped to the parameter
+func tomlSource(tomlPath string) func(string) string {
+	return func(param string) string {
+		if mapping := getParamMapping(param); mapping != nil && mapping.toml != "" {
+			return fmt.Sprintf("%s (%s)", tomlPath, mapping.toml)
+		}
+		return tomlPath
+	}
+}
+
+// flagSource : command line option mapped to the parameter, as seen on the command line
+func flagSource(param string) string {
+	if mapping := getParamMapping(param); mapping != nil && mapping.flag != "" {
+		if opt := getopt.Lookup(mapping.flag); opt != nil && opt.Seen() {
+			return fmt.Sprintf("flag %s", opt.Name())
+		}
+	}
+	return "command line"
+}
+
+// checkExplainFormat : fail on unknown format of the parameters report
+func checkExplainFormat(format string) error {
+	if format != "" && format != ExplainFormatTable && format != ExplainFormatJSON {
+		return fmt.Errorf("Unknown explain format %q. Expected one of: %s, %s", format, ExplainFormatTable, ExplainFormatJSON)
+	}
+	return nil
+}
+
+// productExplanation : provenance of the parameters of one of several products switched at once
+type productExplanation struct {
+	Product    string            `json:"product"`
+	Parameters []ParamProvenance `json:"parameters"`
+}
+
+// WriteExplanation : write final value of each parameter along with its source and the values it overrode
+func WriteExplanation(w io.Writer, params Params) error {
+	if err := checkExplainFormat(params.ExplainFormat); err != nil {
+		return err
+	}
+
+	var products []productExplanation
+	for _, productParams := range params.ProductsParams {
+		products = append(products, productExplanation{Product: productParams.Product, Parameters: productParams.Provenance.Params()})
+	}
+
+	if params.ExplainFormat == ExplainFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Parameters []ParamProvenance    `json:"parameters"`
+			Products   []productExplanation `json:"products,omitempty"`
+		}{Parameters: params.Provenance.Params(), Products: products})
+	}
+
+	if err := writeExplanationTable(w, params.Provenance.Params()); err != nil {
+		return err
+	}
+	for _, product := range products {
+		if _, err := fmt.Fprintf(w, "\nProduct %q:\n", product.Product); err != nil {
+			return err
+		}
+		if err := writeExplanationTable(w, product.Parameters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExplanationTable : write provenance of the parameters as a table
+func writeExplanationTable(w io.Writer, provenance []ParamProvenance) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "PARAMETER\tVALUE\tSOURCE\tOVERRODE")
+	for _, param := range provenance {
+		overridden := make([]string, 0, len(param.Overridden))
+		for _, source := range param.Overridden {
+			overridden = append(overridden, fmt.Sprintf("%s (%s)", formatExplainedValue(source.Value), source.Source))
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", param.Param, formatExplainedValue(param.Value), param.Source, strings.Join(overridden, ", "))
+	}
+	return table.Flush()
+}
+
+// formatExplainedValue : quote strings to make empty values visible
+func formatExplainedValue(value any) string {
+	if str, ok := value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return fmt.Sprint(value)
+}
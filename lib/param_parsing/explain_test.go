@@ -0,0 +1,138 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pborman/getopt"
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestGetParameters_explain(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	t.Setenv("BIN_DIR_FROM_TOML", "/usr/local/bin")
+	t.Setenv("INSTALL_DIR_FROM_TOML", "/tmp")
+	t.Setenv("TF_DEFAULT_VERSION", "1.5.0")
+
+	tomlDir := "../../test-data/integration-tests/test_tfswitchtoml"
+	os.Args = []string{"cmd", "--explain=json", "--chdir=" + tomlDir, "--bin=/usr/test/bin", "1.6.0"}
+	params := initParams(Params{})
+	params.TomlDir = tomlDir
+	params = populateParams(params)
+
+	if params.ExplainFormat != ExplainFormatJSON {
+		t.Errorf("Unexpected explain format. Expected: %q, Actual: %q", ExplainFormatJSON, params.ExplainFormat)
+	}
+
+	tomlPath := filepath.Join(tomlDir, tfSwitchTOMLFileName)
+	expected := map[string]ParamProvenance{
+		"CustomBinaryPath": {
+			ParamSource: ParamSource{Value: "/usr/test/bin", Source: "flag --bin"},
+			Overridden: []ParamSource{
+				{Value: "/usr/local/bin/terraform_from_toml", Source: tomlPath + " (bin)"},
+				{Value: "", Source: sourceDefault},
+			},
+		},
+		"DefaultVersion": {
+			ParamSource: ParamSource{Value: "1.5.0", Source: "environment variable TF_DEFAULT_VERSION"},
+			Overridden: []ParamSource{
+				{Value: "1.5.4", Source: tomlPath + " (default-version)"},
+				{Value: "", Source: sourceDefault},
+			},
+		},
+		"Version": {
+			ParamSource: ParamSource{Value: "1.6.0", Source: sourceCommandLineArg},
+			Overridden: []ParamSource{
+				{Value: "1.6.2", Source: tomlPath + " (version)"},
+				{Value: "", Source: sourceDefault},
+			},
+		},
+		"DryRun": {ParamSource: ParamSource{Value: false, Source: sourceDefault}},
+	}
+	for _, param := range params.Provenance.Params() {
+		expectedParam, found := expected[param.Param]
+		if !found {
+			continue
+		}
+		delete(expected, param.Param)
+		if param.Value != expectedParam.Value || param.Source != expectedParam.Source {
+			t.Errorf("Unexpected provenance of %q. Expected: %v (%s), Actual: %v (%s)", param.Param, expectedParam.Value, expectedParam.Source, param.Value, param.Source)
+		}
+		if len(param.Overridden) != len(expectedParam.Overridden) {
+			t.Errorf("Unexpected overridden values of %q. Expected: %v, Actual: %v", param.Param, expectedParam.Overridden, param.Overridden)
+			continue
+		}
+		for i, overridden := range param.Overridden {
+			if overridden != expectedParam.Overridden[i] {
+				t.Errorf("Unexpected overridden value of %q. Expected: %v, Actual: %v", param.Param, expectedParam.Overridden[i], overridden)
+			}
+		}
+	}
+	for name := range expected {
+		t.Errorf("Missing provenance of %q", name)
+	}
+
+	var output bytes.Buffer
+	if err := WriteExplanation(&output, params); err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	var explanation struct {
+		Parameters []ParamProvenance `json:"parameters"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &explanation); err != nil {
+		t.Fatalf("Could not decode JSON output: %v\n%s", err, output.String())
+	}
+	if len(explanation.Parameters) != len(params.Provenance.Params()) {
+		t.Errorf("Unexpected number of parameters in JSON output. Expected: %d, Actual: %d", len(params.Provenance.Params()), len(explanation.Parameters))
+	}
+
+	output.Reset()
+	params.ExplainFormat = ExplainFormatTable
+	if err := WriteExplanation(&output, params); err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if !strings.Contains(output.String(), `"1.6.0"`) || !strings.Contains(output.String(), sourceCommandLineArg) {
+		t.Errorf("Unexpected table output:\n%s", output.String())
+	}
+
+	params.ExplainFormat = "yaml"
+	if err := WriteExplanation(&output, params); err == nil {
+		t.Error("Expected error for unknown explain format. Got nil")
+	}
+}
+
+func TestGetVersionParams_explain_versiontf(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	dir := t.TempDir()
+	writeTestFile(t, dir, "versions.tf", `terraform {
+  required_version = "~> 1.5.0"
+}
+`)
+	versionsTFPath := filepath.Join(dir, "versions.tf")
+
+	params := initParams(Params{})
+	params.ChDirPath = dir
+	params.MatchVersionRequirement = "1.5.7" // Don't resolve the version from the mirror
+	params.Provenance = newProvenance(params)
+	params = getVersionParams(params)
+
+	for _, param := range params.Provenance.Params() {
+		if param.Param != "VersionRequirement" {
+			continue
+		}
+		// Paths of the configuration files are relative to the working directory
+		if source, _ := filepath.Abs(param.Source); source != versionsTFPath {
+			t.Errorf("Unexpected source of version requirement. Expected: %q, Actual: %q", versionsTFPath, param.Source)
+		}
+		return
+	}
+	t.Error("Missing provenance of \"VersionRequirement\"")
+}
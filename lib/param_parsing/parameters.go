@@ -17,9 +17,11 @@ import (
 type Params struct {
 	Arch                    string
 	ChDirPath               string
+	ConfigCommand           []string // Arguments of `tfswitch config` command, e.g. ["config", "validate"]
 	CustomBinaryPath        string
 	DefaultVersion          string
 	DryRun                  bool
+	ExplainFormat           string
 	ForceColor              bool
 	HelpFlag                bool
 	InstallPath             string
@@ -34,33 +36,216 @@ type Params struct {
 	NoColor                 bool
 	ProductEntity           lib.Product
 	Product                 string
+	ProductsParams          []Params    // Per-product parameters, when more than one product is requested
+	Provenance              *Provenance // Sources of the parameter values, reported with `--explain`
+	SearchBoundary          string
 	ShowLatestFlag          bool
 	ShowLatestPre           string
 	ShowLatestStable        string
 	ShowRequiredFlag        bool
+	StrictConfig            bool
+	StrictVersion           bool
 	TomlDir                 string
+	TrustProjectConfig      bool
 	Version                 string
 	VersionFlag             bool
 	VersionRequirement      string
+	VersionSources          []VersionSource // Versions pinned and constraints declared by the configuration, see CheckVersionConflicts
 }
 
-// This is used to automatically instate Environment variables and TOML keys
-var paramMappings = []struct {
-	description string
-	env         string
-	param       string
-	ptype       reflect.Kind
-	toml        string
-}{
-	{param: "Arch", ptype: reflect.String, env: "TF_ARCH", toml: "arch", description: "CPU architecture"},
-	{param: "CustomBinaryPath", ptype: reflect.String, env: "TF_BINARY_PATH", toml: "bin", description: "Custom binary path"},
-	{param: "DefaultVersion", ptype: reflect.String, env: "TF_DEFAULT_VERSION", toml: "default-version", description: "Default version"},
-	{param: "ForceColor", ptype: reflect.Bool, env: "FORCE_COLOR", toml: "force-color", description: "Force color output if terminal supports it"},
-	{param: "InstallPath", ptype: reflect.String, env: "TF_INSTALL_PATH", toml: "install", description: "Custom install path"},
-	{param: "LogLevel", ptype: reflect.String, env: "TF_LOG_LEVEL", toml: "log-level", description: "Log level"},
-	{param: "NoColor", ptype: reflect.Bool, env: "NO_COLOR", toml: "no-color", description: "Disable color output"},
-	{param: "Product", ptype: reflect.String, env: "TF_PRODUCT", toml: "product", description: "Product"},
-	{param: "Version", ptype: reflect.String, env: "TF_VERSION", toml: "version", description: "Version"},
+// paramMapping : how a parameter is set from the command line, environment variables and TOML configuration files
+type paramMapping struct {
+	param       string       // Name of the Params field
+	ptype       reflect.Kind // Type of the Params field
+	flag        string       // Long command line option, empty if not settable from the command line
+	short       rune         // Short command line option, 0 if none
+	optional    bool         // Whether the value of the command line option may be omitted
+	env         string       // Environment variable, empty if not settable from the environment
+	toml        string       // TOML key, empty if not settable from TOML configuration files
+	userOnly    bool         // Whether the TOML key is ignored in project TOML files, unless the user trusts them (see TrustProjectConfig)
+	description string       // Used in log messages
+	help        string       // Help message of the command line option
+}
+
+// This is used to automatically instate command line options, environment variables and TOML keys.
+// Options which trigger a one-off action instead of configuring the run (help, version, explain) are command line only.
+var paramMappings = []paramMapping{
+	{
+		param: "Arch", ptype: reflect.String, flag: "arch", short: 'A', env: "TF_ARCH", toml: "arch", description: "CPU architecture",
+		help: fmt.Sprintf("Override CPU architecture type for downloaded binary. Ex: `tfswitch --arch amd64` will attempt to download the amd64 version of the binary. Default: %s", runtime.GOARCH),
+	},
+	{
+		param: "ChDirPath", ptype: reflect.String, flag: "chdir", short: 'c', env: "TF_CHDIR", toml: "chdir", description: "Working directory",
+		help: "Switch to a different working directory before executing the given command. Ex: `tfswitch --chdir terraform_project` will run tfswitch in the `terraform_project` directory",
+	},
+	{
+		param: "CustomBinaryPath", ptype: reflect.String, flag: "bin", short: 'b', env: "TF_BINARY_PATH", toml: "bin", userOnly: true, description: "Custom binary path",
+		help: fmt.Sprintf("Custom binary path. Ex: `tfswitch -b %s`", lib.ConvertExecutableExt("/Users/username/bin/terraform")),
+	},
+	{
+		param: "DefaultVersion", ptype: reflect.String, flag: "default", short: 'd', env: "TF_DEFAULT_VERSION", toml: "default-version", description: "Default version",
+		help: "Default to this version in case no other versions could be detected. Ex: `tfswitch --default 1.2.4`",
+	},
+	{
+		param: "DryRun", ptype: reflect.Bool, flag: "dry-run", short: 'r', env: "TF_DRY_RUN", toml: "dry-run", description: "Dry run",
+		help: "Only show what tfswitch would do. Don't download anything",
+	},
+	{
+		param: "ExplainFormat", ptype: reflect.String, flag: "explain", optional: true, description: "Explain format",
+		help: fmt.Sprintf("Print the resolved parameters along with the source of each value (flag, environment variable, configuration file) and the values it overrode, then exit. Optional format: %s (default) or %s. Ex: `tfswitch --explain=%s`", ExplainFormatTable, ExplainFormatJSON, ExplainFormatJSON),
+	},
+	{
+		param: "ForceColor", ptype: reflect.Bool, flag: "force-color", short: 'K', env: "FORCE_COLOR", toml: "force-color", description: "Force color output if terminal supports it",
+		help: "Force color output if terminal supports it",
+	},
+	{
+		param: "HelpFlag", ptype: reflect.Bool, flag: "help", short: 'h', description: "Help",
+		help: "Display help message",
+	},
+	{
+		param: "InstallPath", ptype: reflect.String, flag: "install", short: 'i', env: "TF_INSTALL_PATH", toml: "install", userOnly: true, description: "Custom install path",
+		help: fmt.Sprintf("Custom install path. Ex: `tfswitch -i /Users/username`. The binaries will be in the sub installDir directory e.g. `/Users/username/%s`", lib.InstallDir),
+	},
+	{
+		param: "LatestFlag", ptype: reflect.Bool, flag: "latest", short: 'u', env: "TF_LATEST", toml: "latest", description: "Latest stable version",
+		help: "Get latest stable version",
+	},
+	{
+		param: "LatestPre", ptype: reflect.String, flag: "latest-pre", short: 'p', env: "TF_LATEST_PRE", toml: "latest-pre", description: "Latest pre-release implicit version",
+		help: "Latest pre-release implicit version. Ex: `tfswitch --latest-pre 0.13` downloads 0.13.0-rc1 (latest)",
+	},
+	{
+		param: "LatestStable", ptype: reflect.String, flag: "latest-stable", short: 's', env: "TF_LATEST_STABLE", toml: "latest-stable", description: "Latest implicit version",
+		help: "Latest implicit version based on a constraint. Ex: `tfswitch --latest-stable 0.13.0` downloads 0.13.7 and 0.13 downloads 0.15.5 (latest)",
+	},
+	{
+		param: "ListAllFlag", ptype: reflect.Bool, flag: "list-all", short: 'l', env: "TF_LIST_ALL", toml: "list-all", description: "List all versions",
+		help: "List all versions of product (see `--product`), including Beta and RC versions",
+	},
+	{
+		param: "LogLevel", ptype: reflect.String, flag: "log-level", short: 'g', env: "TF_LOG_LEVEL", toml: "log-level", description: "Log level",
+		help: fmt.Sprintf("Set tfswitch logging level. One of (in the order of increasing level of verbosity): %s. Use `OFF` to disable (suppress) logging", strings.Join(lib.LogLevels(), ", ")),
+	},
+	{
+		param: "MatchVersionRequirement", ptype: reflect.String, flag: "match-version-requirement", short: 'n', env: "TF_MATCH_VERSION_REQUIREMENT", toml: "match-version-requirement", description: "Version to match against the requirement",
+		help: "Check if the requested version matches the requirement mandated by the configuration (env var, module version constraint, config files). Exit successfully if it does (or if there's no requirement found), otherwise exit with a code of `2` (code of `1` denotes a general error)",
+	},
+	{
+		param: "MirrorURL", ptype: reflect.String, flag: "mirror", short: 'm', env: "TF_MIRROR", toml: "mirror", description: "Mirror URL",
+		help: fmt.Sprintf("Install from a remote API other than the default.\nDefault (based on value of `--product`):\n  - %s", strings.Join(defaultMirrorsHelp(lib.Product.GetDefaultMirrorUrl), "\n  - ")),
+	},
+	{
+		param: "MirrorDownloadURL", ptype: reflect.String, flag: "mirror-download", short: 'M', env: "TF_MIRROR_DOWNLOAD", toml: "mirror-download", description: "Download mirror URL",
+		help: fmt.Sprintf("Download artifacts from a URL other than the default.\nDefault (based on value of `--product`):\n  - %s", strings.Join(defaultMirrorsHelp(lib.Product.GetDefaultDownloadMirrorURL), "\n  - ")),
+	},
+	{
+		param: "NoColor", ptype: reflect.Bool, flag: "no-color", short: 'k', env: "NO_COLOR", toml: "no-color", description: "Disable color output",
+		help: "Disable color output. Useful for piping output to a file or when the terminal does not support colors",
+	},
+	{
+		param: "Product", ptype: reflect.String, flag: "product", short: 't', env: "TF_PRODUCT", toml: "product", description: "Product",
+		help: fmt.Sprintf("Specify which product to use. Ex: `tfswitch --product opentofu` will install OpenTofu. Use comma-separated list to switch several products at once. Ex: `tfswitch --product terraform,opentofu`. Options: %s. Default: detected from the working directory (OpenTofu if `*.tofu` files, `.opentofu-version` file or Terragrunt `terraform_binary = \"tofu\"` are found), otherwise %s", strings.Join(productIds(), ", "), lib.DefaultProductId),
+	},
+	{
+		param: "SearchBoundary", ptype: reflect.String, flag: "search-boundary", env: "TF_SEARCH_BOUNDARY", toml: "search-boundary", description: "Search boundary",
+		help: fmt.Sprintf("Stop searching parent directories for version files and constraints at this boundary. One of: %s (repository root, or home directory outside of repositories), %s (home directory), %s (filesystem root), %s (working directory only). Default: %s", SearchBoundaryGit, SearchBoundaryHome, SearchBoundaryRoot, SearchBoundaryNone, defaultSearchBoundary),
+	},
+	{
+		param: "ShowLatestFlag", ptype: reflect.Bool, flag: "show-latest", short: 'U', env: "TF_SHOW_LATEST", toml: "show-latest", description: "Show latest stable version",
+		help: "Show latest stable version",
+	},
+	{
+		param: "ShowLatestPre", ptype: reflect.String, flag: "show-latest-pre", short: 'P', env: "TF_SHOW_LATEST_PRE", toml: "show-latest-pre", description: "Show latest pre-release implicit version",
+		help: "Show latest pre-release implicit version. Ex: `tfswitch --show-latest-pre 0.13` prints 0.13.0-rc1 (latest)",
+	},
+	{
+		param: "ShowLatestStable", ptype: reflect.String, flag: "show-latest-stable", short: 'S', env: "TF_SHOW_LATEST_STABLE", toml: "show-latest-stable", description: "Show latest implicit version",
+		help: "Show latest implicit version. Ex: `tfswitch --show-latest-stable 0.13` prints 0.13.7 (latest)",
+	},
+	{
+		param: "ShowRequiredFlag", ptype: reflect.Bool, flag: "show-required", short: 'R', env: "TF_SHOW_REQUIRED", toml: "show-required", description: "Show required version",
+		help: "Show required (or explicitly requested) version. Defaults to latest version if no constraints found",
+	},
+	{
+		param: "StrictConfig", ptype: reflect.Bool, flag: "strict-config", env: "TF_STRICT_CONFIG", toml: "strict-config", description: "Strict configuration",
+		help: fmt.Sprintf("Fail on unknown keys, values of wrong type and invalid values in %s files instead of ignoring them. See also `tfswitch %s %s`", tfSwitchTOMLFileName, configCommandName, configValidateCommand),
+	},
+	{
+		param: "StrictVersion", ptype: reflect.Bool, flag: "strict", env: "TF_STRICT", toml: "strict", description: "Strict version checks",
+		help: fmt.Sprintf("Fail if a pinned version (version files, `TF_VERSION`, command line) does not satisfy a version constraint (module `required_version`, Terragrunt configuration) instead of warning. Exit with a code of `%d` then", VersionConflictExitCode),
+	},
+	{
+		param: "TrustProjectConfig", ptype: reflect.Bool, env: "TF_TRUST_PROJECT_CONFIG", toml: "trust-project-config", userOnly: true, description: "Trust project TOML config", // Not a command line option, set by the user's configuration
+	},
+	{
+		param: "Version", ptype: reflect.String, env: "TF_VERSION", toml: "version", description: "Version", // Command line argument rather than option
+	},
+	{
+		param: "VersionFlag", ptype: reflect.Bool, flag: "version", short: 'v', description: "Version of tfswitch",
+		help: "Display the version of tfswitch",
+	},
+}
+
+// getParamMapping : mapping of the parameter, nil if it is not mapped
+func getParamMapping(param string) *paramMapping {
+	for idx := range paramMappings {
+		if paramMappings[idx].param == param {
+			return &paramMappings[idx]
+		}
+	}
+	return nil
+}
+
+// getTOMLMapping : mapping of the TOML key, nil if none
+func getTOMLMapping(toml string) *paramMapping {
+	for idx := range paramMappings {
+		if paramMappings[idx].toml == toml {
+			return &paramMappings[idx]
+		}
+	}
+	return nil
+}
+
+// productIds : IDs of the products for help messages
+func productIds() []string {
+	var ids []string
+	for _, product := range lib.GetAllProducts() {
+		ids = append(ids, product.GetId())
+	}
+	return ids
+}
+
+// defaultMirrorsHelp : default mirror of each of the products for help messages
+func defaultMirrorsHelp(mirror func(lib.Product) string) []string {
+	var mirrors []string
+	for _, product := range lib.GetAllProducts() {
+		mirrors = append(mirrors, fmt.Sprintf("%s: %s", product.GetName(), mirror(product)))
+	}
+	return mirrors
+}
+
+// registerFlags : register command line options of paramMappings, bound to the fields of params
+func registerFlags(params *Params) {
+	reflectedParams := reflect.ValueOf(params).Elem()
+	for _, mapping := range paramMappings {
+		if mapping.flag == "" {
+			continue
+		}
+		field := reflectedParams.FieldByName(mapping.param)
+		var opt getopt.Option
+		switch mapping.ptype {
+		case reflect.String:
+			opt = getopt.StringVarLong(field.Addr().Interface().(*string), mapping.flag, mapping.short, mapping.help)
+		case reflect.Bool:
+			opt = getopt.BoolVarLong(field.Addr().Interface().(*bool), mapping.flag, mapping.short, mapping.help)
+		default:
+			logger.Errorf("Internal error: unhandled switch case for \"%s\" type of %q parameter (flag %q)", mapping.ptype, mapping.param, mapping.flag)
+			continue
+		}
+		if mapping.optional {
+			opt.SetOptional()
+		}
+	}
 }
 
 var logger *slog.Logger
@@ -95,7 +280,7 @@ func setupProductParam(params *Params) {
 		// Set default bin directory, if not configured
 		if params.CustomBinaryPath == "" {
 			if runtime.GOOS == "windows" {
-				params.CustomBinaryPath = filepath.Join(lib.GetHomeDirectory(), "bin", lib.ConvertExecutableExt(product.GetExecutableName()))
+				params.CustomBinaryPath = filepath.Join(lib.GetFallbackBinDirectory(), lib.ConvertExecutableExt(product.GetExecutableName()))
 			} else {
 				params.CustomBinaryPath = filepath.Join("/usr/local/bin", product.GetExecutableName())
 			}
@@ -106,46 +291,33 @@ func setupProductParam(params *Params) {
 
 //nolint:gocyclo
 func populateParams(params Params) Params {
-	var productIds []string
-	var defaultMirrors []string
-	var defaultMirrorsDownload []string
-	for _, product := range lib.GetAllProducts() {
-		productIds = append(productIds, product.GetId())
-		defaultMirrors = append(defaultMirrors, fmt.Sprintf("%s: %s", product.GetName(), product.GetDefaultMirrorUrl()))
-		defaultMirrorsDownload = append(defaultMirrorsDownload, fmt.Sprintf("%s: %s", product.GetName(), product.GetDefaultDownloadMirrorURL()))
-	}
+	var products []string
+	var explicitBinaryPath string
+
+	// Logger for resolving the defaults, until the log level is parsed
+	logger = lib.InitLogger(params.LogLevel)
+	setDefaultDirectories(&params)
 
-	// String params
-	getopt.StringVarLong(&params.Arch, "arch", 'A', fmt.Sprintf("Override CPU architecture type for downloaded binary. Ex: `tfswitch --arch amd64` will attempt to download the amd64 version of the binary. Default: %s", runtime.GOARCH))
-	getopt.StringVarLong(&params.ChDirPath, "chdir", 'c', "Switch to a different working directory before executing the given command. Ex: `tfswitch --chdir terraform_project` will run tfswitch in the `terraform_project` directory")
-	getopt.StringVarLong(&params.CustomBinaryPath, "bin", 'b', fmt.Sprintf("Custom binary path. Ex: `tfswitch -b %s`", lib.ConvertExecutableExt("/Users/username/bin/terraform")))
-	getopt.StringVarLong(&params.DefaultVersion, "default", 'd', "Default to this version in case no other versions could be detected. Ex: `tfswitch --default 1.2.4`")
-	getopt.StringVarLong(&params.MatchVersionRequirement, "match-version-requirement", 'n', "Check if the requested version matches the requirement mandated by the configuration (env var, module version constraint, config files). Exit successfully if it does (or if there's no requirement found), otherwise exit with a code of `2` (code of `1` denotes a general error)")
-	getopt.StringVarLong(&params.InstallPath, "install", 'i', fmt.Sprintf("Custom install path. Ex: `tfswitch -i /Users/username`. The binaries will be in the sub installDir directory e.g. `/Users/username/%s`", lib.InstallDir))
-	getopt.StringVarLong(&params.LatestPre, "latest-pre", 'p', "Latest pre-release implicit version. Ex: `tfswitch --latest-pre 0.13` downloads 0.13.0-rc1 (latest)")
-	getopt.StringVarLong(&params.LatestStable, "latest-stable", 's', "Latest implicit version based on a constraint. Ex: `tfswitch --latest-stable 0.13.0` downloads 0.13.7 and 0.13 downloads 0.15.5 (latest)")
-	getopt.StringVarLong(&params.LogLevel, "log-level", 'g', fmt.Sprintf("Set tfswitch logging level. One of (in the order of increasing level of verbosity): %s. Use `OFF` to disable (suppress) logging", strings.Join(lib.LogLevels(), ", ")))
-	getopt.StringVarLong(&params.MirrorURL, "mirror", 'm', fmt.Sprintf("Install from a remote API other than the default.\nDefault (based on value of `--product`):\n  - %s", strings.Join(defaultMirrors, "\n  - ")))
-	getopt.StringVarLong(&params.MirrorDownloadURL, "mirror-download", 'M', fmt.Sprintf("Download artifacts from a URL other than the default.\nDefault (based on value of `--product`):\n  - %s", strings.Join(defaultMirrorsDownload, "\n  - ")))
-	getopt.StringVarLong(&params.ShowLatestPre, "show-latest-pre", 'P', "Show latest pre-release implicit version. Ex: `tfswitch --show-latest-pre 0.13` prints 0.13.0-rc1 (latest)")
-	getopt.StringVarLong(&params.ShowLatestStable, "show-latest-stable", 'S', "Show latest implicit version. Ex: `tfswitch --show-latest-stable 0.13` prints 0.13.7 (latest)")
-	getopt.StringVarLong(&params.Product, "product", 't', fmt.Sprintf("Specify which product to use. Ex: `tfswitch --product opentofu` will install OpenTofu. Options: %s. Default: %s", strings.Join(productIds, ", "), lib.DefaultProductId))
-
-	// Bool params
-	getopt.BoolVarLong(&params.DryRun, "dry-run", 'r', "Only show what tfswitch would do. Don't download anything")
-	getopt.BoolVarLong(&params.ForceColor, "force-color", 'K', "Force color output if terminal supports it")
-	getopt.BoolVarLong(&params.HelpFlag, "help", 'h', "Display help message")
-	getopt.BoolVarLong(&params.LatestFlag, "latest", 'u', "Get latest stable version")
-	getopt.BoolVarLong(&params.ListAllFlag, "list-all", 'l', "List all versions of product (see `--product`), including Beta and RC versions")
-	getopt.BoolVarLong(&params.NoColor, "no-color", 'k', "Disable color output. Useful for piping output to a file or when the terminal does not support colors")
-	getopt.BoolVarLong(&params.ShowLatestFlag, "show-latest", 'U', "Show latest stable version")
-	getopt.BoolVarLong(&params.ShowRequiredFlag, "show-required", 'R', "Show required (or explicitly requested) version. Defaults to latest version if no constraints found")
-	getopt.BoolVarLong(&params.VersionFlag, "version", 'v', "Display the version of tfswitch")
+	registerFlags(&params)
+
+	params.Provenance = newProvenance(params)
 
 	// Parse the command line parameters to fetch stuff like chdir
 	getopt.Parse()
+	params.Provenance.record(params, flagSource)
+	// Flags are bound to the parameters, so keep the value before the configuration layers overwrite it
+	var chDirFlag string
+	if opt := getopt.Lookup("chdir"); opt != nil && opt.Seen() {
+		chDirFlag = params.ChDirPath
+	}
 
-	isNotShortRun := !params.VersionFlag && !params.HelpFlag
+	// `tfswitch config <command>` operates on the configuration files only
+	if args := getopt.Args(); len(args) > 0 && args[0] == configCommandName {
+		params.ConfigCommand = args
+		logger = lib.InitLogger(params.LogLevel)
+	}
+
+	isNotShortRun := !params.VersionFlag && !params.HelpFlag && len(params.ConfigCommand) == 0
 
 	if isNotShortRun {
 		if params.ForceColor && params.NoColor {
@@ -165,15 +337,39 @@ func populateParams(params Params) Params {
 
 		oldLogLevel := params.LogLevel
 		logger = lib.InitLogger(params.LogLevel)
+		if err := checkExplainFormat(params.ExplainFormat); err != nil {
+			logger.Fatal(err)
+		}
 
 		var err error
 		// Read configuration files
-		// TOML from Homedir
-		if tomlFileExists(params) {
-			params, err = getParamsTOML(params)
+		// TOML from Homedir, then TOML of the project (working directory or its parents) layered over it
+		tomlValidator := &tomlValidator{}
+		for idx, findTOMLFile := range []func(Params) string{getHomeTOMLFile, findProjectTOMLFile} {
+			tomlPath := findTOMLFile(params)
+			if tomlPath == "" {
+				continue
+			}
+			if isStrictConfig(params) {
+				tomlValidator.trustProjectConfig = isProjectConfigTrusted(params)
+				if err := tomlValidator.checkTOMLFileStrict(tomlPath, idx == 0); err != nil {
+					logger.Fatalf("Invalid TOML config (strict mode): %v", err)
+				}
+			}
+			tomlVersion := params.Version
+			if idx == 0 {
+				params, err = getParamsFromTOMLFile(params, tomlPath)
+			} else { // Project file, which may come with any cloned repository
+				params, err = getParamsFromProjectTOMLFile(params, tomlPath)
+			}
 			if err != nil {
-				logger.Fatalf("Failed to obtain settings from TOML config in home directory: %v", err)
+				logger.Fatalf("Failed to obtain settings from TOML config %q: %v", tomlPath, err)
 			}
+			if params.Version != tomlVersion {
+				params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: tomlPath, Version: params.Version})
+			}
+			params = applyChDirFlag(params, chDirFlag)
+			params.Provenance.record(params, tomlSource(tomlPath))
 
 			if params.ForceColor && params.NoColor {
 				logger.Fatal("(toml) Cannot force color and disable color at the same time. Please choose either of them.")
@@ -194,8 +390,29 @@ func populateParams(params Params) Params {
 
 		// First pass to obtain environment variables to override product
 		params = GetParamsFromEnvironment(params)
+		params = applyChDirFlag(params, chDirFlag)
+		params.Provenance.record(params, envSource)
+
+		// Product from command line determines products to resolve settings for (CLI always wins)
+		if opt := getopt.Lookup("product"); opt != nil && opt.Seen() {
+			params.Product = opt.String()
+			params.Provenance.record(params, flagSource)
+		}
+		// Infer product from the working directory, unless set explicitly
+		if params.Product == "" {
+			params.Product = detectProduct(params.ChDirPath)
+			params.Provenance.record(params, fixedSource(sourceDetectedProduct))
+		}
+		products = splitProducts(params.Product)
+		if len(products) > 1 {
+			checkMultiProductParams(params)
+		}
+		explicitBinaryPath = params.CustomBinaryPath
+		params.Product = products[0]
+		params.Provenance.record(params, fixedSource(fmt.Sprintf("first of products %s", strings.Join(products, ", "))))
 
 		setupProductParam(&params)
+		params.Provenance.record(params, fixedSource(fmt.Sprintf("default of %q product", params.Product)))
 
 		if params.ForceColor && params.NoColor {
 			logger.Fatal("(env) Cannot force color and disable color at the same time. Please choose either of them.")
@@ -214,31 +431,8 @@ func populateParams(params Params) Params {
 			logger.Fatalf("Cannot read working directory: %q", params.ChDirPath)
 		}
 
-		if tfSwitchFileExists(params) {
-			params, err = GetParamsFromTfSwitch(params)
-			if err != nil {
-				logger.Fatalf("Failed to obtain settings from \".tfswitch\" file: %v", err)
-			}
-		}
-
-		if terraformVersionFileExists(params) {
-			params, err = GetParamsFromTerraformVersion(params)
-			if err != nil {
-				logger.Fatalf("Failed to obtain settings from \".terraform-version\" file: %v", err)
-			}
-		}
-
-		params, err = GetVersionFromVersionsTF(params)
-		if err != nil {
-			logger.Fatalf("Failed to obtain settings from Terraform module: %v", err)
-		}
-
-		params, err = GetVersionFromTerragrunt(params)
-		if err != nil {
-			logger.Fatalf("Failed to obtain settings from Terragrunt configuration: %v", err)
-		}
-
-		params = GetParamsFromEnvironment(params)
+		params = getVersionParams(params)
+		params.Product = products[0]
 
 		// Logger config was changed by the config files. Reinitialise.
 		if params.LogLevel != oldLogLevel {
@@ -248,13 +442,22 @@ func populateParams(params Params) Params {
 
 	// Parse again to overwrite anything that might be defined on the command line AND in any config file (CLI always wins)
 	getopt.Parse()
+	params.Provenance.record(params, flagSource)
+	if opt := getopt.Lookup("explain"); opt != nil && opt.Seen() && params.ExplainFormat == "" {
+		params.ExplainFormat = ExplainFormatTable
+	}
 	args := getopt.Args()
+	if len(args) == 1 && isNotShortRun && len(products) > 1 {
+		logger.Fatalf("Version provided on command line cannot be used with multiple products (%s)", strings.Join(products, ", "))
+	}
 	if len(args) == 1 && isNotShortRun { // Disregard args if "short" run (version or help)
 		/* version provided on command line as arg */
 		logger = lib.InitLogger(params.LogLevel)
 		logger.Infof("Reading version provided on command line: %s", args[0])
 		params.Version = args[0]
 		params.VersionRequirement = params.Version // version from cmdline takes highest precedence
+		params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: sourceCommandLineArg, Version: params.Version})
+		params.Provenance.record(params, fixedSource(sourceCommandLineArg))
 	}
 
 	if isNotShortRun {
@@ -277,21 +480,198 @@ func populateParams(params Params) Params {
 		logger.Debugf("Resolved mirror URL: %q", params.MirrorURL)
 		logger.Debugf("Resolved no color: %t", params.NoColor)
 		logger.Debugf("Resolved product name: %q", params.Product)
+		logger.Debugf("Resolved search boundary: %q", params.SearchBoundary)
 		logger.Debugf("Resolved working directory: %q", params.ChDirPath)
+
+		if len(products) > 1 {
+			if opt := getopt.Lookup("bin"); opt != nil && opt.Seen() {
+				explicitBinaryPath = params.CustomBinaryPath // CLI always wins
+			}
+			params.ProductsParams = getProductsParams(params, products, explicitBinaryPath)
+		}
+	}
+
+	return params
+}
+
+// getVersionParams : read version and version constraints of the product from the configuration files
+func getVersionParams(params Params) Params {
+	var err error
+	if toolVersionsFileExists(params) {
+		params, err = GetParamsFromToolVersions(params)
+		if err != nil {
+			logger.Fatalf("Failed to obtain settings from %q file: %v", toolVersionsFileName, err)
+		}
+		params.Provenance.record(params, fixedSource(findVersionFile(params, toolVersionsFileName)))
+	}
+
+	if tfSwitchFileExists(params) {
+		params, err = GetParamsFromTfSwitch(params)
+		if err != nil {
+			logger.Fatalf("Failed to obtain settings from \".tfswitch\" file: %v", err)
+		}
+		params.Provenance.record(params, fixedSource(findVersionFile(params, tfSwitchFileName)))
+	}
+
+	if versionFileExists(params) {
+		params, err = GetParamsFromVersionFile(params)
+		if err != nil {
+			logger.Fatalf("Failed to obtain settings from %q product version file: %v", params.Product, err)
+		}
+		params.Provenance.record(params, fixedSource(findProductVersionFile(params)))
+	}
+
+	versionSourceCount := len(params.VersionSources)
+	params, err = GetVersionFromVersionsTF(params)
+	if err != nil {
+		logger.Fatalf("Failed to obtain settings from Terraform module: %v", err)
+	}
+	params.Provenance.record(params, versionSourcesSource(params, versionSourceCount, fmt.Sprintf("%s at %q", paramTypeVersionTF, params.ChDirPath)))
+
+	versionSourceCount = len(params.VersionSources)
+	params, err = GetVersionFromTerragrunt(params)
+	if err != nil {
+		logger.Fatalf("Failed to obtain settings from Terragrunt configuration: %v", err)
+	}
+	params.Provenance.record(params, versionSourcesSource(params, versionSourceCount, fmt.Sprintf("%s configuration at %q", paramTypeTerragrunt, params.ChDirPath)))
+
+	// The working directory is resolved already: the version files were read from it
+	chDirPath := params.ChDirPath
+	params = GetParamsFromEnvironment(params)
+	params.ChDirPath = chDirPath
+	if versionEnv := getParamMapping("Version").env; os.Getenv(versionEnv) != "" {
+		if params.Version, err = resolveVersion(params, params.Version, versionEnv); err != nil {
+			logger.Fatalf("Failed to obtain version from %q environment variable: %v", versionEnv, err)
+		}
+		params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: versionEnv, Version: params.Version})
 	}
+	params.Provenance.record(params, envSource)
+	return params
+}
 
+// applyChDirFlag : working directory from the command line, if set (CLI always wins).
+// Applied over each configuration layer, as the working directory determines where the project
+// configuration and version files are looked up.
+func applyChDirFlag(params Params, chDirFlag string) Params {
+	if chDirFlag != "" {
+		params.ChDirPath = chDirFlag
+	}
 	return params
 }
 
+// splitProducts : split comma-separated list of products, defaulting to the default product
+func splitProducts(product string) []string {
+	var products []string
+	for _, id := range strings.Split(product, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			products = append(products, id)
+		}
+	}
+	if len(products) == 0 {
+		return []string{lib.DefaultProductId}
+	}
+	return lib.RemoveDuplicateStrings(products)
+}
+
+// checkMultiProductParams : fail on parameters which are ambiguous when switching several products at once
+func checkMultiProductParams(params Params) {
+	for _, param := range []struct {
+		name  string
+		isSet bool
+	}{
+		{name: "default", isSet: params.DefaultVersion != ""},
+		{name: "latest-pre", isSet: params.LatestPre != ""},
+		{name: "latest-stable", isSet: params.LatestStable != ""},
+		{name: "match-version-requirement", isSet: params.MatchVersionRequirement != ""},
+		{name: "mirror", isSet: params.MirrorURL != ""},
+		{name: "mirror-download", isSet: params.MirrorDownloadURL != ""},
+		{name: "show-latest-pre", isSet: params.ShowLatestPre != ""},
+		{name: "show-latest-stable", isSet: params.ShowLatestStable != ""},
+		{name: "version", isSet: params.Version != ""},
+	} {
+		if param.isSet {
+			logger.Fatalf("%q parameter cannot be used with multiple products (%s)", param.name, params.Product)
+		}
+	}
+}
+
+// getProductsParams : resolve product-specific parameters (mirrors, binary path and version) for each product.
+// Explicit binary path is only used for its directory, as each product has its own executable.
+// The first product is the one the top-level parameters were resolved for, so they are reused.
+func getProductsParams(params Params, products []string, explicitBinaryPath string) []Params {
+	productsParams := make([]Params, 0, len(products))
+	for idx, id := range products {
+		productParams := params
+		productParams.Provenance = params.Provenance.fork(params)
+		productParams.ProductsParams = nil
+		if idx == 0 {
+			productParams.Product = id // Set to the list of products on the command line
+			if explicitBinaryPath != "" {
+				productParams.CustomBinaryPath = getProductBinaryPath(explicitBinaryPath, id)
+				productParams.Provenance.record(productParams, fixedSource(fmt.Sprintf("resolved for %q product", id)))
+			}
+			logger.Debugf("Reusing top-level parameters for %q product", id)
+			logger.Debugf("Resolved %q product binary path: %q", id, productParams.CustomBinaryPath)
+			logger.Debugf("Resolved %q product install version: %q", id, productParams.Version)
+			productsParams = append(productsParams, productParams)
+			continue
+		}
+
+		productParams.Product = id
+		productParams.ProductEntity = nil
+		productParams.MirrorURL = ""
+		productParams.MirrorDownloadURL = ""
+		productParams.Version = ""
+		productParams.VersionRequirement = ""
+		productParams.VersionSources = nil
+		productParams.CustomBinaryPath = ""
+
+		logger.Infof("Resolving parameters of %q product", id)
+		productParams.Provenance.record(productParams, fixedSource(fmt.Sprintf("resolved for %q product", id)))
+		setupProductParam(&productParams)
+		productParams.Provenance.record(productParams, fixedSource(fmt.Sprintf("default of %q product", id)))
+		productParams = getVersionParams(productParams)
+		productParams.Product = id
+		// After getVersionParams, as the environment (e.g. TF_BINARY_PATH) is applied again there
+		if explicitBinaryPath != "" {
+			productParams.CustomBinaryPath = getProductBinaryPath(explicitBinaryPath, id)
+			productParams.Provenance.record(productParams, fixedSource(fmt.Sprintf("resolved for %q product", id)))
+		}
+
+		logger.Debugf("Resolved %q product binary path: %q", id, productParams.CustomBinaryPath)
+		logger.Debugf("Resolved %q product install version: %q", id, productParams.Version)
+		productsParams = append(productsParams, productParams)
+	}
+	return productsParams
+}
+
+// getProductBinaryPath : path of the product executable in the directory of the explicit binary path
+func getProductBinaryPath(explicitBinaryPath string, id string) string {
+	product := lib.GetProductById(id)
+	return filepath.Join(filepath.Dir(explicitBinaryPath), lib.ConvertExecutableExt(product.GetExecutableName()))
+}
+
+// setDefaultDirectories : resolve default directories which are not set yet.
+// Not in initParams, as resolving them may log (e.g. ignored XDG base directories).
+func setDefaultDirectories(params *Params) {
+	if params.InstallPath == "" {
+		params.InstallPath = lib.GetDataDirectory()
+	}
+	if params.TomlDir == "" {
+		params.TomlDir = lib.LookupHomeDirectory()
+	}
+}
+
 func initParams(params Params) Params {
 	params.Arch = runtime.GOARCH
 	params.ChDirPath = lib.GetCurrentDirectory()
 	params.CustomBinaryPath = ""
 	params.DefaultVersion = lib.DefaultLatest
 	params.DryRun = false
+	params.ExplainFormat = ""
 	params.ForceColor = false
 	params.HelpFlag = false
-	params.InstallPath = lib.GetHomeDirectory()
+	params.InstallPath = "" // Resolved by setDefaultDirectories, once the logger is initialised
 	params.LatestFlag = false
 	params.LatestPre = lib.DefaultLatest
 	params.LatestStable = lib.DefaultLatest
@@ -304,9 +684,13 @@ func initParams(params Params) Params {
 	params.ShowLatestPre = lib.DefaultLatest
 	params.ShowLatestStable = lib.DefaultLatest
 	params.ShowRequiredFlag = false
-	params.TomlDir = lib.GetHomeDirectory()
+	params.StrictConfig = false
+	params.StrictVersion = false
+	params.TrustProjectConfig = false
+	params.TomlDir = "" // Resolved by setDefaultDirectories, once the logger is initialised
 	params.Version = lib.DefaultLatest
-	params.Product = lib.DefaultProductId
+	params.Product = "" // Detected from the working directory, unless set explicitly
+	params.SearchBoundary = defaultSearchBoundary
 	params.VersionFlag = false
 	return params
 }
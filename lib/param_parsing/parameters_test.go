@@ -6,11 +6,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/gookit/color"
+	"github.com/mitchellh/go-homedir"
 	"github.com/pborman/getopt"
 	"github.com/warrensbox/terraform-switcher/lib"
 )
@@ -346,6 +348,73 @@ product = "opentofu"
 	os.Unsetenv("TF_VERSION")
 }
 
+func TestGetParameters_multiple_products(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	t.Setenv("TF_PRODUCT", "")
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, ".tfswitchrc", "1.5.7") // Applies to any product, unlike `.terraform-version`
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + tempDir, "--product=terraform, opentofu", "--bin=/opt/bin/terraform"}
+	params := initParams(Params{})
+	params.TomlDir = tempDir
+	params = populateParams(params)
+	checkExpectedProductsParams(t, params, "/opt/bin")
+
+	t.Log("Testing with binary path from environment variable")
+	t.Setenv("TF_BINARY_PATH", "/opt/env/bin/terraform")
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + tempDir, "--product=terraform,opentofu"}
+	params = initParams(Params{})
+	params.TomlDir = tempDir
+	params = populateParams(params)
+	checkExpectedProductsParams(t, params, "/opt/env/bin")
+	t.Setenv("TF_BINARY_PATH", "")
+
+	t.Log("Testing with TOML configuration")
+	writeTestFile(t, tempDir, ".tfswitch.toml", `product = ["terraform", "opentofu"]`)
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + tempDir}
+	params = initParams(Params{})
+	params.TomlDir = tempDir
+	params = populateParams(params)
+	checkExpectedProductsParams(t, params, "")
+	// The first product is resolved by the top-level parameters already
+	if !reflect.DeepEqual(params.ProductsParams[0].Provenance.Params(), params.Provenance.Params()) {
+		t.Errorf("Parameters of %q product resolved again. Expected: %v, Actual: %v", "terraform", params.Provenance.Params(), params.ProductsParams[0].Provenance.Params())
+	}
+}
+
+func checkExpectedProductsParams(t *testing.T, params Params, binDir string) {
+	t.Helper()
+	if len(params.ProductsParams) != 2 {
+		t.Fatalf("Expected parameters for 2 products. Got %d", len(params.ProductsParams))
+	}
+	for idx, id := range []string{"terraform", "opentofu"} {
+		productParams := params.ProductsParams[idx]
+		product := lib.GetProductById(id)
+		if productParams.Product != id || productParams.ProductEntity.GetId() != id {
+			t.Errorf("Product not matching. Expected: %q, Actual: %q", id, productParams.Product)
+		}
+		if productParams.MirrorURL != product.GetDefaultMirrorUrl() {
+			t.Errorf("Mirror URL of %q not matching. Expected: %q, Actual: %q", id, product.GetDefaultMirrorUrl(), productParams.MirrorURL)
+		}
+		if expected := "1.5.7"; productParams.Version != expected {
+			t.Errorf("Version of %q not matching. Expected: %q, Actual: %q", id, expected, productParams.Version)
+		}
+		if binDir != "" {
+			if expected := filepath.Join(binDir, lib.ConvertExecutableExt(product.GetExecutableName())); productParams.CustomBinaryPath != expected {
+				t.Errorf("Binary path of %q not matching. Expected: %q, Actual: %q", id, expected, productParams.CustomBinaryPath)
+			}
+		} else if filepath.Base(productParams.CustomBinaryPath) != lib.ConvertExecutableExt(product.GetExecutableName()) {
+			t.Errorf("Binary path of %q not matching executable name. Actual: %q", id, productParams.CustomBinaryPath)
+		}
+	}
+}
+
 func TestVersionFlagOutput(t *testing.T) {
 	flagName := "--version"
 	expectedOutput := "Version: "
@@ -505,3 +574,94 @@ func TestNoAndForceColorFlagsOutput(t *testing.T) {
 		t.Logf("Success: %q", expectedOutput)
 	}
 }
+
+func TestParamMappings(t *testing.T) {
+	paramsType := reflect.TypeOf(Params{})
+	seen := map[string]string{}
+	for _, mapping := range paramMappings {
+		field, found := paramsType.FieldByName(mapping.param)
+		if !found {
+			t.Errorf("Parameter %q is not a field of Params", mapping.param)
+			continue
+		}
+		if field.Type.Kind() != mapping.ptype {
+			t.Errorf("Parameter %q is %s, but mapped as %s", mapping.param, field.Type.Kind(), mapping.ptype)
+		}
+		if mapping.flag != "" && mapping.help == "" {
+			t.Errorf("Parameter %q flag %q has no help message", mapping.param, mapping.flag)
+		}
+		for _, name := range []string{"flag " + mapping.flag, "short " + string(mapping.short), "env " + mapping.env, "toml " + mapping.toml} {
+			if strings.HasSuffix(name, " ") || strings.HasSuffix(name, "\x00") {
+				continue
+			}
+			if param, duplicate := seen[name]; duplicate {
+				t.Errorf("Parameters %q and %q are both mapped to %s", param, mapping.param, name)
+			}
+			seen[name] = mapping.param
+		}
+	}
+}
+
+func TestGetParameters_empty_home(t *testing.T) {
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	// Built before HOME is changed (Go build cache). Run in a fresh process, as the logger is not yet initialised when the defaults are resolved
+	binaryPath := filepath.Join(t.TempDir(), "tfswitch")
+	if out, err := exec.Command("go", "build", "-o", binaryPath, "../../").CombinedOutput(); err != nil {
+		t.Fatalf("Unexpected failure: \"%v\", output: %q", err, string(out))
+	}
+
+	homeDir := t.TempDir()
+	disableCache := homedir.DisableCache
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = disableCache })
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	for _, envVar := range []string{lib.TFSwitchHomeEnvVar, "XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_CACHE_HOME"} {
+		t.Setenv(envVar, "")
+	}
+
+	out, err := exec.Command(binaryPath, "--version").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Unexpected failure: \"%v\", output: %q", err, string(out))
+	}
+	if expected := "Version: "; !strings.HasPrefix(string(out), expected) {
+		t.Errorf("Expected %q, got: %q", expected, string(out))
+	}
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--version"}
+	params := GetParameters()
+	if params.InstallPath != homeDir {
+		t.Errorf("InstallPath Param was not as expected. Actual: %q, Expected: %q", params.InstallPath, homeDir)
+	}
+	if params.TomlDir != homeDir {
+		t.Errorf("TomlDir Param was not as expected. Actual: %q, Expected: %q", params.TomlDir, homeDir)
+	}
+}
+
+func TestGetParameters_chdir_flag_over_env(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	cliDir := t.TempDir()
+	envDir := t.TempDir()
+	writeTestFile(t, cliDir, ".tfswitchrc", "1.5.7")
+	writeTestFile(t, envDir, ".tfswitchrc", "1.1.1")
+	t.Setenv("TF_CHDIR", envDir)
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + cliDir}
+	params := initParams(Params{})
+	params.TomlDir = t.TempDir()
+	params = populateParams(params)
+
+	if params.ChDirPath != cliDir {
+		t.Errorf("Working directory not matching. Expected: %q, Actual: %q", cliDir, params.ChDirPath)
+	}
+	if expected := "1.5.7"; params.Version != expected {
+		t.Errorf("Version not read from working directory set on command line. Expected: %q, Actual: %q", expected, params.Version)
+	}
+}
@@ -0,0 +1,82 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/warrensbox/terraform-switcher/lib"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	openTofuProductId    = "opentofu" // nolint:revive // FIXME: var-naming: const openTofuProductId should be openTofuProductID (revive)
+	terragruntBinaryAttr = "terraform_binary"
+)
+
+// detectProduct : infer product from the content of the directory, defaulting to lib.DefaultProductId
+func detectProduct(dir string) string {
+	openTofu := lib.GetProductById(openTofuProductId)
+	for _, versionFileName := range openTofu.GetVersionFileNames() {
+		if lib.CheckFileExist(filepath.Join(dir, versionFileName)) {
+			logger.Infof("Detected %s product from %q file", openTofu.GetName(), versionFileName)
+			return openTofuProductId
+		}
+	}
+
+	for _, pattern := range []string{"*.tofu", "*.tofu.json"} {
+		if matches, _ := filepath.Glob(filepath.Join(dir, pattern)); len(matches) > 0 {
+			logger.Infof("Detected %s product from %q file", lib.GetProductById(openTofuProductId).GetName(), matches[0])
+			return openTofuProductId
+		}
+	}
+
+	if product, filePath := detectProductFromTerragrunt(dir); product != nil {
+		logger.Infof("Detected %s product from %q attribute in %q", product.GetName(), terragruntBinaryAttr, filePath)
+		return product.GetId()
+	}
+
+	logger.Debugf("No product detected in %q, defaulting to %q", dir, lib.DefaultProductId)
+	return lib.DefaultProductId
+}
+
+// detectProductFromTerragrunt : find product by executable name set in `terraform_binary` attribute of Terragrunt configuration
+func detectProductFromTerragrunt(dir string) (lib.Product, string) {
+	for _, terragruntFileName := range terragruntFileNamesNew() {
+		filePath := filepath.Join(dir, terragruntFileName)
+		if !lib.IsRegularFile(filePath) {
+			continue
+		}
+
+		hclFile, diagnostics := hclparse.NewParser().ParseHCLFile(filePath)
+		if diagnostics.HasErrors() {
+			logger.Debugf("Unable to parse %s HCL file %q", paramTypeTerragrunt, filePath)
+			continue
+		}
+		content, _, diagnostics := hclFile.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: terragruntBinaryAttr}},
+		})
+		attr, found := content.Attributes[terragruntBinaryAttr]
+		if diagnostics.HasErrors() || !found {
+			continue
+		}
+
+		// Only literal values are supported, as the attribute may be set by arbitrary expression
+		value, diagnostics := attr.Expr.Value(nil)
+		if diagnostics.HasErrors() || !value.Type().Equals(cty.String) || !value.IsKnown() || value.IsNull() {
+			logger.Debugf("Skipping non-literal %q attribute in %q", terragruntBinaryAttr, filePath)
+			continue
+		}
+
+		executable := strings.TrimSuffix(filepath.Base(value.AsString()), ".exe")
+		for _, product := range lib.GetAllProducts() {
+			if product.GetExecutableName() == executable {
+				return product, filePath
+			}
+		}
+		logger.Debugf("No product with %q executable found for %q attribute in %q", executable, terragruntBinaryAttr, filePath)
+	}
+	return nil, ""
+}
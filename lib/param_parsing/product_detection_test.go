@@ -0,0 +1,70 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pborman/getopt"
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestDetectProduct(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	for name, test := range map[string]struct {
+		fileName    string
+		fileContent string
+		expected    string
+	}{
+		"no files":                       {expected: "terraform"},
+		"terraform files":                {fileName: "main.tf", fileContent: `terraform {}`, expected: "terraform"},
+		"opentofu version file":          {fileName: ".opentofu-version", fileContent: "1.8.0", expected: "opentofu"},
+		"tofu files":                     {fileName: "main.tofu", fileContent: `terraform {}`, expected: "opentofu"},
+		"tofu JSON files":                {fileName: "main.tofu.json", fileContent: `{}`, expected: "opentofu"},
+		"terragrunt tofu binary":         {fileName: "terragrunt.hcl", fileContent: `terraform_binary = "tofu"`, expected: "opentofu"},
+		"terragrunt tofu binary path":    {fileName: "terragrunt.hcl", fileContent: `terraform_binary = "/usr/local/bin/tofu"`, expected: "opentofu"},
+		"terragrunt terraform binary":    {fileName: "terragrunt.hcl", fileContent: `terraform_binary = "terraform"`, expected: "terraform"},
+		"terragrunt expression binary":   {fileName: "terragrunt.hcl", fileContent: `terraform_binary = get_env("TG_BINARY", "tofu")`, expected: "terraform"},
+		"terragrunt without binary":      {fileName: "terragrunt.hcl", fileContent: `terraform_version_constraint = "~> 1.5"`, expected: "terraform"},
+		"terragrunt with blocks":         {fileName: "terragrunt.hcl", fileContent: "terraform_binary = \"tofu\"\n\nterraform {\n  source = \"../modules\"\n}\n", expected: "opentofu"},
+		"terragrunt with unknown binary": {fileName: "terragrunt.hcl", fileContent: `terraform_binary = "unknown"`, expected: "terraform"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if test.fileName != "" {
+				writeTestFile(t, tempDir, test.fileName, test.fileContent)
+			}
+			if actual := detectProduct(tempDir); actual != test.expected {
+				t.Errorf("Detected product not matching. Expected: %q, Actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetParameters_detected_product_is_overridden(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Setenv("TF_PRODUCT", "")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, "main.tofu", `terraform {}`)
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + tempDir}
+	params := initParams(Params{})
+	params.TomlDir = tempDir
+	params = populateParams(params)
+	if expected := "opentofu"; params.Product != expected || params.ProductEntity.GetId() != expected {
+		t.Errorf("Detected product not matching. Expected: %q, Actual: %q", expected, params.Product)
+	}
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + tempDir, "--product=terraform"}
+	params = initParams(Params{})
+	params.TomlDir = tempDir
+	params = populateParams(params)
+	if expected := "terraform"; params.Product != expected || params.ProductEntity.GetId() != expected {
+		t.Errorf("Explicit product not matching. Expected: %q, Actual: %q", expected, params.Product)
+	}
+}
@@ -0,0 +1,112 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+// Boundaries of the search for version files and constraints in parent directories
+const (
+	SearchBoundaryGit  = "git"  // Repository root (directory containing `.git`), or home directory outside of repositories
+	SearchBoundaryHome = "home" // Home directory, or filesystem root outside of home directory
+	SearchBoundaryRoot = "root" // Filesystem root
+	SearchBoundaryNone = "none" // Working directory only
+
+	defaultSearchBoundary = SearchBoundaryGit
+	gitDirName            = ".git"
+)
+
+var searchBoundaries = []string{SearchBoundaryGit, SearchBoundaryHome, SearchBoundaryRoot, SearchBoundaryNone}
+
+// getSearchDirs : list directories to look up version files and constraints in, nearest first:
+// the working directory followed by its parent directories up to the search boundary (inclusive)
+func getSearchDirs(params Params) []string {
+	boundary := params.SearchBoundary
+	if boundary == "" {
+		boundary = defaultSearchBoundary
+	} else if !slices.Contains(searchBoundaries, boundary) {
+		logger.Warnf("Invalid search boundary %q (expected one of %q), defaulting to %q", boundary, searchBoundaries, defaultSearchBoundary)
+		boundary = defaultSearchBoundary
+	}
+
+	relPath, err := lib.GetRelativePath(params.ChDirPath)
+	if err != nil {
+		logger.Warnf("Could not derive relative path to %q: %v", params.ChDirPath, err)
+		relPath = params.ChDirPath
+	}
+	searchDirs := []string{relPath}
+	if boundary == SearchBoundaryNone {
+		return searchDirs
+	}
+
+	dir, err := filepath.Abs(params.ChDirPath)
+	if err != nil {
+		logger.Warnf("Could not derive absolute path to %q: %v", params.ChDirPath, err)
+		return searchDirs
+	}
+	homeDir := lib.LookupHomeDirectory()
+
+	for {
+		if boundary == SearchBoundaryGit && lib.CheckFileExist(filepath.Join(dir, gitDirName)) {
+			logger.Tracef("Reached repository root %q", dir)
+			break
+		}
+		if (boundary == SearchBoundaryGit || boundary == SearchBoundaryHome) && homeDir != "" && dir == filepath.Clean(homeDir) {
+			logger.Tracef("Reached home directory %q", dir)
+			break
+		}
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			logger.Tracef("Reached filesystem root %q", dir)
+			break
+		}
+		dir = parentDir
+
+		if parentRelPath, err := lib.GetRelativePath(dir); err == nil {
+			searchDirs = append(searchDirs, parentRelPath)
+		} else {
+			searchDirs = append(searchDirs, dir)
+		}
+	}
+
+	logger.Tracef("Directories to search (up to %q boundary): %q", boundary, searchDirs)
+	return searchDirs
+}
+
+// getVersionFileNames : version files, in the order of increasing precedence within the same directory
+func getVersionFileNames(params Params) []string {
+	return append([]string{toolVersionsFileName, tfSwitchFileName}, getProductVersionFileNames(params)...)
+}
+
+// findVersionFile : path to the version file in the nearest directory containing any of the version files,
+// empty if there is no such file in that directory (a nearer version file of another kind wins).
+// `.tool-versions` files not pinning the product are disregarded, as they usually pin other tools too.
+func findVersionFile(params Params, fileName string) string {
+	for _, dir := range getSearchDirs(params) {
+		var filePath string
+		var found bool
+		for _, versionFileName := range getVersionFileNames(params) {
+			versionFilePath := filepath.Join(dir, versionFileName)
+			if !lib.IsRegularFile(versionFilePath) {
+				continue
+			}
+			if versionFileName == toolVersionsFileName {
+				if version, _ := getVersionFromToolVersions(versionFilePath, getProductIdOrDefault(params)); version == "" {
+					logger.Debugf("No %s version found in %q", getProductIdOrDefault(params), versionFilePath)
+					continue
+				}
+			}
+			found = true
+			if versionFileName == fileName {
+				filePath = versionFilePath
+			}
+		}
+		if found {
+			return filePath
+		}
+	}
+	return ""
+}
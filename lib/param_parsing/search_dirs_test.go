@@ -0,0 +1,124 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+// createTestRepo : create repository with nested module directory, returning paths to both
+func createTestRepo(t *testing.T) (string, string) {
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	moduleDir := filepath.Join(repoDir, "live", "module")
+	for _, dir := range []string{filepath.Join(repoDir, gitDirName), moduleDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repoDir, moduleDir
+}
+
+func TestGetSearchDirs(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+
+	for boundary, expectedLen := range map[string]int{
+		SearchBoundaryGit:  3,
+		SearchBoundaryNone: 1,
+		"invalid":          3,
+	} {
+		t.Run(boundary, func(t *testing.T) {
+			searchDirs := getSearchDirs(Params{ChDirPath: moduleDir, SearchBoundary: boundary})
+			if len(searchDirs) != expectedLen {
+				t.Fatalf("Expected %d directories, got %d: %q", expectedLen, len(searchDirs), searchDirs)
+			}
+			if actual, _ := filepath.Abs(searchDirs[len(searchDirs)-1]); boundary != SearchBoundaryNone && actual != repoDir {
+				t.Errorf("Expected search to stop at %q, got %q", repoDir, actual)
+			}
+		})
+	}
+
+	t.Run(SearchBoundaryRoot, func(t *testing.T) {
+		searchDirs := getSearchDirs(Params{ChDirPath: moduleDir, SearchBoundary: SearchBoundaryRoot})
+		if actual, _ := filepath.Abs(searchDirs[len(searchDirs)-1]); actual != filepath.Dir(actual) {
+			t.Errorf("Expected search to stop at filesystem root, got %q", actual)
+		}
+	})
+}
+
+func TestGetParams_parent_directories(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	liveDir := filepath.Dir(moduleDir)
+	writeTestFile(t, filepath.Dir(repoDir), tfSwitchFileName, "0.1.0") // Beyond the boundary
+	writeTestFile(t, repoDir, tfSwitchFileName, "1.5.0")
+
+	params, err := GetParamsFromTfSwitch(Params{ChDirPath: moduleDir})
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "1.5.0"; params.Version != expected {
+		t.Errorf("Expected version from repository root %q, got %q", expected, params.Version)
+	}
+
+	// Nearest version file wins, even if of lower precedence within the same directory
+	writeTestFile(t, liveDir, ".terraform-version", "1.6.0")
+	writeTestFile(t, moduleDir, tfSwitchFileName, "1.7.0")
+	if !tfSwitchFileExists(Params{ChDirPath: moduleDir}) || versionFileExists(Params{ChDirPath: moduleDir}) {
+		t.Errorf("Expected only %q in the nearest directory to be used", tfSwitchFileName)
+	}
+	params, _ = GetParamsFromTfSwitch(Params{ChDirPath: moduleDir})
+	if expected := "1.7.0"; params.Version != expected {
+		t.Errorf("Expected version from module directory %q, got %q", expected, params.Version)
+	}
+
+	// Search is restricted to the working directory
+	params, _ = GetParamsFromVersionFile(Params{ChDirPath: liveDir, SearchBoundary: SearchBoundaryNone})
+	if expected := "1.6.0"; params.Version != expected {
+		t.Errorf("Expected version from working directory %q, got %q", expected, params.Version)
+	}
+	params, _ = GetParamsFromTfSwitch(Params{ChDirPath: liveDir, SearchBoundary: SearchBoundaryNone})
+	if params.Version != "" {
+		t.Errorf("Expected no version, got %q", params.Version)
+	}
+}
+
+func TestGetConstraints_parent_directories(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	writeTestFile(t, filepath.Dir(moduleDir), "versions.tf", `terraform { required_version = "~> 1.5.0" }`)
+	writeTestFile(t, repoDir, "versions.tf", `terraform { required_version = "~> 1.4.0" }`)
+	writeTestFile(t, repoDir, "terragrunt.hcl", `terraform_version_constraint = ">= 1.3"`)
+
+	params := Params{ChDirPath: moduleDir, Product: "terraform"}
+	params, err := getConstraintFromVersionsTF(params)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "~> 1.5.0"; params.VersionRequirement != expected {
+		t.Errorf("Expected nearest version constraint %q, got %q", expected, params.VersionRequirement)
+	}
+
+	// Module without version constraint doesn't inherit constraint of the parent directories
+	writeTestFile(t, moduleDir, "main.tf", `resource "null_resource" "test" {}`)
+	params = Params{ChDirPath: moduleDir, Product: "terraform"}
+	params, err = getConstraintFromVersionsTF(params)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if params.VersionRequirement != "" {
+		t.Errorf("Expected no version constraint for module without constraint, got %q", params.VersionRequirement)
+	}
+
+	params = Params{ChDirPath: moduleDir, Product: "terraform", MatchVersionRequirement: "1.5.0"}
+	params, err = GetVersionFromTerragrunt(params)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := ">= 1.3"; params.VersionRequirement != expected {
+		t.Errorf("Expected version constraint from %s at repository root %q, got %q", paramTypeTerragrunt, expected, params.VersionRequirement)
+	}
+}
@@ -3,9 +3,13 @@ package param_parsing
 
 import (
 	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
 )
 
+// TestGetParamsFromTerraformVersion : deprecated wrapper still reads `.terraform-version`
 func TestGetParamsFromTerraformVersion(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
 	var params Params
 	params.ChDirPath = "../../test-data/integration-tests/test_terraform-version"
 	params, err := GetParamsFromTerraformVersion(params)
@@ -17,27 +21,3 @@ func TestGetParamsFromTerraformVersion(t *testing.T) {
 		t.Errorf("Version from .terraform-version not read correctly. Got: %v, Expect: %v", params.Version, expected)
 	}
 }
-
-func TestGetParamsFromTerraformVersion_no_version(t *testing.T) {
-	var params Params
-	params.ChDirPath = "../../test-data/skip-integration-tests/test_versiontf_no_version_constraint"
-	params, err := GetParamsFromTerraformVersion(params)
-	if err != nil {
-		t.Fatalf("Got error '%s'", err)
-	}
-	if params.Version != "" {
-		t.Errorf("Expected empty version string. Got: %v", params.Version)
-	}
-}
-
-func TestGetParamsFromTerraformVersion_no_file(t *testing.T) {
-	var params Params
-	params.ChDirPath = "../../test-data/skip-integration-tests/test_no_file"
-	params, err := GetParamsFromTerraformVersion(params)
-	if err != nil {
-		t.Fatalf("Got error '%s'", err)
-	}
-	if params.Version != "" {
-		t.Errorf("Expected empty version string. Got: %v", params.Version)
-	}
-}
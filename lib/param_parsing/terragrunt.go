@@ -5,9 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
-	"github.com/hashicorp/hcl/v2/gohcl"
-	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/warrensbox/terraform-switcher/lib"
 )
 
@@ -19,8 +18,22 @@ const (
 	terragruntConfigEnvVarName = "TF_TERRAGRUNT_CONFIG_FILE_NAME"
 )
 
-type terragruntVersionConstraints struct {
-	TerraformVersionConstraint string `hcl:"terraform_version_constraint"`
+const (
+	terraformVersionConstraintAttrName  = "terraform_version_constraint"
+	terragruntVersionConstraintAttrName = "terragrunt_version_constraint"
+)
+
+// terragruntConstraintAttrName : name of the attribute constraining version of the product.
+// Terragrunt itself is constrained by `terragrunt_version_constraint`, while
+// `terraform_version_constraint` applies to products consuming Terraform configuration
+func terragruntConstraintAttrName(product lib.Product) string {
+	if product.GetId() == "terragrunt" {
+		return terragruntVersionConstraintAttrName
+	}
+	if slices.Contains(product.GetFileExtensions(), "tf") {
+		return terraformVersionConstraintAttrName
+	}
+	return ""
 }
 
 func terragruntFileNamesNew() []string {
@@ -49,16 +62,27 @@ func terragruntFileNamesNew() []string {
 }
 
 func GetVersionFromTerragrunt(params Params) (Params, error) {
-	relPath, errRelPath := lib.GetRelativePath(params.ChDirPath)
-	if errRelPath != nil {
-		return params, errRelPath
+	product := getProductOrDefault(params)
+	if product == nil {
+		return params, fmt.Errorf("unknown product %q", params.Product)
+	}
+	attrName := terragruntConstraintAttrName(product)
+	if attrName == "" {
+		logger.Debugf("%s configuration has no version constraint for %s", paramTypeTerragrunt, product.GetName())
+		return params, nil
 	}
 
-	var versionFromTerragrunt terragruntVersionConstraints
+	var versionConstraint string
 
-	// Iterate over possible Terragrunt files and break on first found version constraint
-	for _, terragruntFileName := range terragruntFileNamesNew() {
-		filePath := filepath.Join(relPath, terragruntFileName)
+	// Iterate over possible Terragrunt files in the nearest directories first and break on first found version constraint
+	var filePaths []string
+	fileNames := terragruntFileNamesNew()
+	for _, relPath := range getSearchDirs(params) {
+		for _, terragruntFileName := range fileNames {
+			filePaths = append(filePaths, filepath.Join(relPath, terragruntFileName))
+		}
+	}
+	for _, filePath := range filePaths {
 		if !lib.IsRegularFile(filePath) {
 			if lib.CheckFileExist(filePath) {
 				logger.Warnf("Skipping non-regular %s configuration file %q", paramTypeTerragrunt, filePath)
@@ -69,36 +93,36 @@ func GetVersionFromTerragrunt(params Params) (Params, error) {
 		}
 
 		logger.Infof("Reading %s configuration from %q", paramTypeTerragrunt, filePath)
-		parser := hclparse.NewParser()
-		hclFile, diagnostics := parser.ParseHCLFile(filePath)
-		if diagnostics.HasErrors() {
-			logger.Errorf("Unable to parse %s HCL file %q", paramTypeTerragrunt, filePath)
+		config, err := parseTerragruntConfig(filePath, nil)
+		if err != nil {
+			logger.Errorf("Unable to evaluate %s configuration %q: %v", paramTypeTerragrunt, filePath, err)
 			continue
 		}
-		diagnostics = gohcl.DecodeBody(hclFile.Body, nil, &versionFromTerragrunt)
-		// Do not fail on failure to decode the body, as it may e.g. miss a required block or
-		// have an unexpected attribute, though we don't want to fail execution because of that
-		if diagnostics.HasErrors() {
-			logger.Errorf(diagnostics.Error())
-		}
 
-		if versionFromTerragrunt.TerraformVersionConstraint != "" {
-			params.VersionRequirement = versionFromTerragrunt.TerraformVersionConstraint
+		versionConstraint, err = config.lookupString(attrName)
+		if err != nil {
+			logger.Errorf("Unable to evaluate %q in %s configuration %q: %v", attrName, paramTypeTerragrunt, filePath, err)
+			continue
+		}
+		if versionConstraint != "" {
+			params.VersionRequirement = versionConstraint
+			params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: filePath, Constraint: versionConstraint})
 			logger.Debugf("Version requirement from %s configuration at %q: %q", paramTypeTerragrunt, filePath, params.VersionRequirement)
 			break
 		}
 
-		logger.Debugf("No terraform version constraint found in %s configuration at %q", paramTypeTerragrunt, filePath)
+		logger.Debugf("No %q found in %s configuration at %q", attrName, paramTypeTerragrunt, filePath)
 	}
 
 	// No version constraint found in any Terragrunt file, return as is
-	if versionFromTerragrunt.TerraformVersionConstraint == "" {
+	if versionConstraint == "" {
 		return params, nil
 	}
 
 	// Resolve version from the found version constraint, if version match arg was not supplied
 	if params.MatchVersionRequirement == "" {
-		version, err := lib.GetSemver(params.ProductEntity, params.VersionRequirement, params.MirrorURL)
+		_, mirrorURL := getVersionsMirror(params)
+		version, err := lib.GetSemver(product, params.VersionRequirement, mirrorURL)
 		if err != nil {
 			return params, fmt.Errorf("no version found matching %q", params.VersionRequirement)
 		}
@@ -0,0 +1,362 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/warrensbox/terraform-switcher/lib"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+const (
+	terragruntIncludeBlockType  = "include"
+	terragruntLocalsBlockType   = "locals"
+	terragruntDefaultParentFile = "terragrunt.hcl" // Looked up by `find_in_parent_folders()` without arguments
+	terragruntMaxConfigDepth    = 16               // Maximum nesting of included and read configurations
+)
+
+// terragruntConfig : evaluated Terragrunt configuration: its locals and top-level attributes,
+// including attributes inherited from included configurations
+type terragruntConfig struct {
+	locals     map[string]cty.Value
+	attributes map[string]cty.Value
+	errors     map[string]error // Attributes which could not be evaluated
+}
+
+// terragruntScope : directories the Terragrunt functions resolve paths against. As in Terragrunt, included
+// configurations are evaluated in the scope of the configuration including them (e.g. `find_in_parent_folders()`
+// in `root.hcl` searches the parents of the including `terragrunt.hcl`)
+type terragruntScope struct {
+	terragruntDir string            // Directory of the configuration being processed
+	includeDirs   map[string]string // Directories of the configurations it includes, by label
+}
+
+// getIncludeDir : directory of the included configuration with the label, of the only one if no label given
+func (s *terragruntScope) getIncludeDir(args []cty.Value) (string, error) {
+	if len(args) > 0 {
+		label := args[0].AsString()
+		if dir, found := s.includeDirs[label]; found {
+			return dir, nil
+		}
+		return "", fmt.Errorf("no %q block labelled %q", terragruntIncludeBlockType, label)
+	}
+	switch len(s.includeDirs) {
+	case 0:
+		return "", nil
+	case 1:
+		for _, dir := range s.includeDirs {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("several %q blocks, label required", terragruntIncludeBlockType)
+}
+
+// toValue : configuration as returned by `read_terragrunt_config()` and exposed by `include.<label>`
+func (c terragruntConfig) toValue() cty.Value {
+	values := map[string]cty.Value{terragruntLocalsBlockType: cty.ObjectVal(c.locals)}
+	for name, value := range c.attributes {
+		values[name] = value
+	}
+	return cty.ObjectVal(values)
+}
+
+// getString : value of the string attribute, empty if not set or not a string
+func (c terragruntConfig) getString(attrName string) string {
+	value, found := c.attributes[attrName]
+	if !found || !value.IsWhollyKnown() || value.IsNull() || !value.Type().Equals(cty.String) {
+		return ""
+	}
+	return value.AsString()
+}
+
+// lookupString : value of the string attribute, empty if not set or not a string,
+// error if it is set but could not be evaluated
+func (c terragruntConfig) lookupString(attrName string) (string, error) {
+	if err, found := c.errors[attrName]; found {
+		return "", err
+	}
+	return c.getString(attrName), nil
+}
+
+// parseTerragruntConfig : evaluate Terragrunt configuration file, resolving locals, includes and
+// the subset of Terragrunt functions relevant to locating configuration files.
+// Stack holds absolute paths of the configurations being evaluated, to detect circular references.
+func parseTerragruntConfig(filePath string, stack []string) (terragruntConfig, error) {
+	return parseTerragruntConfigInScope(filePath, nil, stack)
+}
+
+// parseTerragruntConfigInScope : evaluate Terragrunt configuration file in the scope of the configuration
+// including it, or in its own scope if scope is nil
+func parseTerragruntConfigInScope(filePath string, scope *terragruntScope, stack []string) (terragruntConfig, error) {
+	config := terragruntConfig{locals: map[string]cty.Value{}, attributes: map[string]cty.Value{}, errors: map[string]error{}}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return config, err
+	}
+	if slices.Contains(stack, absPath) {
+		return config, fmt.Errorf("circular reference to %q", filePath)
+	}
+	if len(stack) >= terragruntMaxConfigDepth {
+		return config, fmt.Errorf("too deeply nested configuration at %q", filePath)
+	}
+	stack = append(slices.Clone(stack), absPath)
+
+	hclFile, diagnostics := hclparse.NewParser().ParseHCLFile(absPath)
+	if diagnostics.HasErrors() {
+		return config, fmt.Errorf("could not parse %q: %v", filePath, diagnostics.Error())
+	}
+	body, ok := hclFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return config, fmt.Errorf("unsupported syntax of %q", filePath)
+	}
+
+	isIncluded := scope != nil
+	if !isIncluded {
+		scope = &terragruntScope{terragruntDir: filepath.Dir(absPath), includeDirs: map[string]string{}}
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{},
+		Functions: terragruntFunctions(scope, stack),
+	}
+
+	// Included configurations, exposed as `include.<label>`
+	var includePaths, includeLabels []string
+	for _, block := range body.Blocks {
+		if block.Type != terragruntIncludeBlockType {
+			continue
+		}
+		pathAttr, found := block.Body.Attributes["path"]
+		if !found {
+			logger.Debugf("Skipping %q block without %q attribute in %q", terragruntIncludeBlockType, "path", filePath)
+			continue
+		}
+		value, diagnostics := pathAttr.Expr.Value(ctx)
+		if diagnostics.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.Type().Equals(cty.String) {
+			logger.Debugf("Skipping %q block with unresolved path in %q: %v", terragruntIncludeBlockType, filePath, diagnostics.Error())
+			continue
+		}
+		var label string
+		if len(block.Labels) > 0 {
+			label = block.Labels[0]
+		}
+		includePath := resolveTerragruntPath(filepath.Dir(absPath), value.AsString())
+		includePaths = append(includePaths, includePath)
+		includeLabels = append(includeLabels, label)
+		if !isIncluded { // Functions refer to the includes of the configuration being processed only
+			scope.includeDirs[label] = filepath.Dir(includePath)
+		}
+	}
+	var included []terragruntConfig
+	includes := map[string]cty.Value{}
+	for idx, includePath := range includePaths {
+		logger.Debugf("Including %s configuration %q from %q", paramTypeTerragrunt, includePath, filePath)
+		includedConfig, err := parseTerragruntConfigInScope(includePath, scope, stack)
+		if err != nil {
+			return config, err
+		}
+		included = append(included, includedConfig)
+		if includeLabels[idx] != "" {
+			includes[includeLabels[idx]] = includedConfig.toValue()
+		}
+	}
+	ctx.Variables[terragruntIncludeBlockType] = cty.ObjectVal(includes)
+
+	// Locals may reference each other, so evaluate them until no more can be resolved
+	pending := map[string]*hclsyntax.Attribute{}
+	for _, block := range body.Blocks {
+		if block.Type == terragruntLocalsBlockType {
+			for name, attr := range block.Body.Attributes {
+				pending[name] = attr
+			}
+		}
+	}
+	localErrors := map[string]error{}
+	for len(pending) > 0 {
+		ctx.Variables["local"] = cty.ObjectVal(config.locals)
+		resolved := false
+		for name, attr := range pending {
+			value, diagnostics := attr.Expr.Value(ctx)
+			if diagnostics.HasErrors() {
+				localErrors[name] = diagnostics
+				continue
+			}
+			config.locals[name] = value
+			delete(localErrors, name)
+			delete(pending, name)
+			resolved = true
+		}
+		if !resolved {
+			for name := range pending {
+				logger.Debugf("Could not evaluate local %q in %q: %v", name, filePath, localErrors[name])
+			}
+			break
+		}
+	}
+	ctx.Variables["local"] = cty.ObjectVal(config.locals)
+
+	for name, attr := range body.Attributes {
+		value, diagnostics := attr.Expr.Value(ctx)
+		if diagnostics.HasErrors() {
+			logger.Debugf("Could not evaluate %q attribute in %q: %v", name, filePath, diagnostics.Error())
+			config.errors[name] = attributeError(attr, diagnostics, localErrors)
+			continue
+		}
+		config.attributes[name] = value
+	}
+
+	// Attributes not set in the configuration itself are inherited from the included ones
+	for _, includedConfig := range included {
+		for name, value := range includedConfig.attributes {
+			if _, found := config.attributes[name]; !found && config.errors[name] == nil {
+				config.attributes[name] = value
+			}
+		}
+		for name, err := range includedConfig.errors {
+			if _, found := config.attributes[name]; !found && config.errors[name] == nil {
+				config.errors[name] = err
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// attributeError : cause of the attribute evaluation failure, the failure of the local it refers to if any
+func attributeError(attr *hclsyntax.Attribute, diagnostics hcl.Diagnostics, localErrors map[string]error) error {
+	for _, traversal := range attr.Expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if step, ok := traversal[1].(hcl.TraverseAttr); ok && localErrors[step.Name] != nil {
+			return fmt.Errorf("could not evaluate local %q: %v", step.Name, localErrors[step.Name])
+		}
+	}
+	return diagnostics
+}
+
+// resolveTerragruntPath : resolve path relative to the directory of the configuration
+func resolveTerragruntPath(dir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// terragruntFunctions : functions available in the configuration evaluated in the scope
+func terragruntFunctions(scope *terragruntScope, stack []string) map[string]function.Function {
+	dir := scope.terragruntDir
+	return map[string]function.Function{
+		"find_in_parent_folders": function.New(&function.Spec{
+			VarParam: &function.Parameter{Name: "args", Type: cty.String},
+			Type:     function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				fileName := terragruntDefaultParentFile
+				if len(args) > 0 {
+					fileName = args[0].AsString()
+				}
+				for current := filepath.Dir(dir); ; current = filepath.Dir(current) {
+					if filePath := filepath.Join(current, fileName); lib.CheckFileExist(filePath) {
+						return cty.StringVal(filePath), nil
+					}
+					if filepath.Dir(current) == current {
+						break
+					}
+				}
+				if len(args) > 1 {
+					return args[1], nil
+				}
+				return cty.NilVal, fmt.Errorf("could not find %q in parent folders of %q", fileName, dir)
+			},
+		}),
+		"read_terragrunt_config": function.New(&function.Spec{
+			Params:   []function.Parameter{{Name: "config_path", Type: cty.String}},
+			VarParam: &function.Parameter{Name: "default", Type: cty.DynamicPseudoType},
+			Type:     function.StaticReturnType(cty.DynamicPseudoType),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				config, err := parseTerragruntConfig(resolveTerragruntPath(dir, args[0].AsString()), stack)
+				if err != nil {
+					if len(args) > 1 {
+						return args[1], nil
+					}
+					return cty.NilVal, err
+				}
+				return config.toValue(), nil
+			},
+		}),
+		"get_terragrunt_dir": function.New(&function.Spec{
+			Type: function.StaticReturnType(cty.String),
+			Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+				return cty.StringVal(dir), nil
+			},
+		}),
+		"get_parent_terragrunt_dir": function.New(&function.Spec{
+			VarParam: &function.Parameter{Name: "name", Type: cty.String},
+			Type:     function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				includeDir, err := scope.getIncludeDir(args)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				if includeDir == "" { // Not including any configuration
+					return cty.StringVal(dir), nil
+				}
+				return cty.StringVal(includeDir), nil
+			},
+		}),
+		"path_relative_to_include": function.New(&function.Spec{
+			VarParam: &function.Parameter{Name: "name", Type: cty.String},
+			Type:     function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				includeDir, err := scope.getIncludeDir(args)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				if includeDir == "" { // Not including any configuration
+					return cty.StringVal("."), nil
+				}
+				relPath, err := filepath.Rel(includeDir, dir)
+				if err != nil {
+					return cty.NilVal, err
+				}
+				return cty.StringVal(filepath.ToSlash(relPath)), nil
+			},
+		}),
+		"get_env": function.New(&function.Spec{
+			Params:   []function.Parameter{{Name: "name", Type: cty.String}},
+			VarParam: &function.Parameter{Name: "default", Type: cty.String},
+			Type:     function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+				if value, found := os.LookupEnv(args[0].AsString()); found {
+					return cty.StringVal(value), nil
+				}
+				if len(args) > 1 {
+					return args[1], nil
+				}
+				return cty.StringVal(""), nil
+			},
+		}),
+		"can":       tryfunc.CanFunc,
+		"coalesce":  stdlib.CoalesceFunc,
+		"concat":    stdlib.ConcatFunc,
+		"format":    stdlib.FormatFunc,
+		"join":      stdlib.JoinFunc,
+		"lookup":    stdlib.LookupFunc,
+		"lower":     stdlib.LowerFunc,
+		"merge":     stdlib.MergeFunc,
+		"replace":   stdlib.ReplaceFunc,
+		"split":     stdlib.SplitFunc,
+		"trimspace": stdlib.TrimSpaceFunc,
+		"try":       tryfunc.TryFunc,
+		"upper":     stdlib.UpperFunc,
+	}
+}
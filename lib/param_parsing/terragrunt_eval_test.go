@@ -0,0 +1,177 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestParseTerragruntConfig(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	liveDir := filepath.Dir(moduleDir)
+	t.Setenv("TG_TEST_TOFU_VERSION", "1.8")
+
+	writeTestFile(t, repoDir, "root.hcl", `
+locals {
+  major_version = "1"
+  version       = "${local.major_version}.${local.minor_version}"
+  minor_version = "6"
+}
+terraform_version_constraint = "~> ${local.version}.0"
+`)
+	writeTestFile(t, repoDir, "versions.hcl", `
+locals {
+  terragrunt_version = "0.58.0"
+}
+`)
+	writeTestFile(t, liveDir, "terragrunt.hcl", `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+locals {
+  versions = read_terragrunt_config(find_in_parent_folders("versions.hcl"))
+}
+terragrunt_version_constraint = "= ${local.versions.locals.terragrunt_version}"
+`)
+	writeTestFile(t, moduleDir, "terragrunt.hcl", `
+include "live" {
+  path = "../terragrunt.hcl"
+}
+locals {
+  root_version = include.live.terraform_version_constraint
+}
+terraform_version_constraint = format(">= %s", get_env("TG_TEST_TOFU_VERSION", "1.5"))
+`)
+
+	for dir, expected := range map[string]map[string]string{
+		repoDir:   {terraformVersionConstraintAttrName: "~> 1.6.0", terragruntVersionConstraintAttrName: ""},
+		liveDir:   {terraformVersionConstraintAttrName: "~> 1.6.0", terragruntVersionConstraintAttrName: "= 0.58.0"},
+		moduleDir: {terraformVersionConstraintAttrName: ">= 1.8", terragruntVersionConstraintAttrName: "= 0.58.0"},
+	} {
+		fileName := "terragrunt.hcl"
+		if dir == repoDir {
+			fileName = "root.hcl"
+		}
+		config, err := parseTerragruntConfig(filepath.Join(dir, fileName), nil)
+		if err != nil {
+			t.Fatalf("Expected no error for %q. Got: %v", dir, err)
+		}
+		for attrName, expectedValue := range expected {
+			if actual := config.getString(attrName); actual != expectedValue {
+				t.Errorf("Unexpected %q in %q. Expected: %q, Actual: %q", attrName, dir, expectedValue, actual)
+			}
+		}
+	}
+
+	// Constraint resolved through the include chain
+	params := Params{ChDirPath: liveDir, Product: "terraform", MatchVersionRequirement: "1.6.0"}
+	params, err := GetVersionFromTerragrunt(params)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "~> 1.6.0"; params.VersionRequirement != expected {
+		t.Errorf("Unexpected version requirement. Expected: %q, Actual: %q", expected, params.VersionRequirement)
+	}
+}
+
+func TestParseTerragruntConfig_include_scope(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	liveDir := filepath.Dir(moduleDir)
+
+	// Functions in the included root.hcl resolve paths from the including configuration
+	writeTestFile(t, repoDir, "root.hcl", `
+locals {
+  env        = read_terragrunt_config(find_in_parent_folders("env.hcl"))
+  relative   = path_relative_to_include()
+  parent_dir = get_parent_terragrunt_dir()
+  dir        = get_terragrunt_dir()
+}
+terraform_version_constraint = local.env.locals.terraform_version
+`)
+	writeTestFile(t, liveDir, "env.hcl", `
+locals {
+  terraform_version = "~> 1.7.0"
+}
+`)
+	writeTestFile(t, moduleDir, "terragrunt.hcl", `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+locals {
+  relative   = path_relative_to_include("root")
+  parent_dir = get_parent_terragrunt_dir()
+  root       = include.root.locals
+}
+`)
+	config, err := parseTerragruntConfig(filepath.Join(moduleDir, "terragrunt.hcl"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "~> 1.7.0"; config.getString(terraformVersionConstraintAttrName) != expected {
+		t.Errorf("Unexpected %q. Expected: %q, Actual: %q", terraformVersionConstraintAttrName, expected, config.getString(terraformVersionConstraintAttrName))
+	}
+	rootLocals := config.locals["root"].AsValueMap()
+	for name, expected := range map[string]string{"relative": "live/module", "parent_dir": repoDir} {
+		if actual := config.locals[name].AsString(); actual != expected {
+			t.Errorf("Unexpected local %q. Expected: %q, Actual: %q", name, expected, actual)
+		}
+		if actual := rootLocals[name].AsString(); actual != expected {
+			t.Errorf("Unexpected local %q of included configuration. Expected: %q, Actual: %q", name, expected, actual)
+		}
+	}
+	if actual := rootLocals["dir"].AsString(); actual != moduleDir {
+		t.Errorf("Unexpected local %q of included configuration. Expected: %q, Actual: %q", "dir", moduleDir, actual)
+	}
+
+	// Constraint of the root configuration alone cannot be evaluated, which is reported
+	config, err = parseTerragruntConfig(filepath.Join(repoDir, "root.hcl"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if _, err := config.lookupString(terraformVersionConstraintAttrName); err == nil || !strings.Contains(err.Error(), `local "env"`) {
+		t.Errorf("Expected error naming the unresolved local. Got: %v", err)
+	}
+}
+
+func TestParseTerragruntConfig_errors(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	_, moduleDir := createTestRepo(t)
+
+	writeTestFile(t, moduleDir, "a.hcl", `include "b" { path = "b.hcl" }`)
+	writeTestFile(t, moduleDir, "b.hcl", `include "a" { path = "a.hcl" }`)
+	if _, err := parseTerragruntConfig(filepath.Join(moduleDir, "a.hcl"), nil); err == nil {
+		t.Error("Expected error for circular include. Got nil")
+	}
+
+	writeTestFile(t, moduleDir, "missing.hcl", `include "root" { path = "root.hcl" }`)
+	if _, err := parseTerragruntConfig(filepath.Join(moduleDir, "missing.hcl"), nil); err == nil {
+		t.Error("Expected error for missing included configuration. Got nil")
+	}
+
+	// Unresolvable values are skipped rather than failing the whole configuration
+	writeTestFile(t, moduleDir, "unresolved.hcl", `
+locals {
+  from_dependency = dependency.vpc.outputs.id
+}
+terraform_version_constraint = read_terragrunt_config("nonexistent.hcl", { locals = { version = ">= 1.0" } }).locals.version
+terragrunt_version_constraint = local.from_dependency
+`)
+	config, err := parseTerragruntConfig(filepath.Join(moduleDir, "unresolved.hcl"), nil)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := ">= 1.0"; config.getString(terraformVersionConstraintAttrName) != expected {
+		t.Errorf("Expected default of read_terragrunt_config() %q, got %q", expected, config.getString(terraformVersionConstraintAttrName))
+	}
+	if actual := config.getString(terragruntVersionConstraintAttrName); actual != "" {
+		t.Errorf("Expected unresolved attribute to be skipped, got %q", actual)
+	}
+	if _, err := config.lookupString(terragruntVersionConstraintAttrName); err == nil || !strings.Contains(err.Error(), `local "from_dependency"`) {
+		t.Errorf("Expected error naming the unresolved local. Got: %v", err)
+	}
+}
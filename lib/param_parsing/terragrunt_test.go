@@ -193,3 +193,58 @@ func TestGetVersionFromTerragrunt_erroneous_file(t *testing.T) {
 		t.Fatalf("Expected version %q, got %q", expected, params.Version)
 	}
 }
+
+func TestGetVersionFromTerragrunt_product_constraint(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	tests := map[string]string{
+		"terraform":  ">= 0.13, < 0.14",
+		"opentofu":   ">= 0.13, < 0.14",
+		"terragrunt": "= 0.36.2",
+		"packer":     "",
+	}
+	for product, expected := range tests {
+		var params Params
+		params = initParams(params)
+		params.ChDirPath = "../../test-data/integration-tests/test_terragrunt_hcl"
+		params.Product = product
+		params.MatchVersionRequirement = "0.0.1" // Skip resolving the constraint
+		setupProductParam(&params)
+		params, err := GetVersionFromTerragrunt(params)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if params.VersionRequirement != expected {
+			t.Errorf("Expected %s version requirement %q, got %q", product, expected, params.VersionRequirement)
+		}
+	}
+}
+
+func TestGetVersionFromTerragrunt_default_product(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, "terragrunt.hcl", `terraform_version_constraint = ">= 1.5"`)
+	writeTestFile(t, moduleDir, "versions.tf", `terraform { required_version = ">= 1.6" }`)
+
+	// Product is empty until detected from the working directory
+	params := initParams(Params{})
+	params.ChDirPath = moduleDir
+	params.MatchVersionRequirement = "1.6.0"
+	params, err := GetVersionFromTerragrunt(params)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := ">= 1.5"; params.VersionRequirement != expected {
+		t.Errorf("Expected version requirement %q, got %q", expected, params.VersionRequirement)
+	}
+	if params, err = GetVersionFromVersionsTF(params); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := ">= 1.6"; params.VersionRequirement != expected {
+		t.Errorf("Expected version requirement %q, got %q", expected, params.VersionRequirement)
+	}
+
+	params.Product = "nonexistent"
+	if _, err = GetVersionFromTerragrunt(params); err == nil {
+		t.Error("Expected error for unknown product. Got nil")
+	}
+}
@@ -1,32 +1,26 @@
 //nolint:revive // FIXME: don't use an underscore in package name
 package param_parsing
 
-import (
-	"os"
-	"path/filepath"
-	"strings"
-
-	"github.com/warrensbox/terraform-switcher/lib"
-)
-
 const tfSwitchFileName = ".tfswitchrc"
 
 func GetParamsFromTfSwitch(params Params) (Params, error) {
-	filePath := filepath.Join(params.ChDirPath, tfSwitchFileName)
-	if lib.CheckFileExist(filePath) {
+	filePath := findVersionFile(params, tfSwitchFileName)
+	if filePath != "" {
 		logger.Infof("Reading configuration from %q", filePath)
-		content, err := os.ReadFile(filePath)
+		version, err := readVersionFile(filePath)
 		if err != nil {
 			logger.Errorf("Could not read file content from %q: %v", filePath, err)
 			return params, err
 		}
-		params.Version = strings.TrimSpace(string(content))
+		if params.Version, err = resolveVersion(params, version, filePath); err != nil {
+			return params, err
+		}
+		params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: filePath, Version: params.Version})
 		logger.Debugf("Using version from %q: %q", filePath, params.Version)
 	}
 	return params, nil
 }
 
 func tfSwitchFileExists(params Params) bool {
-	filePath := filepath.Join(params.ChDirPath, tfSwitchFileName)
-	return lib.CheckFileExist(filePath)
+	return findVersionFile(params, tfSwitchFileName) != ""
 }
@@ -2,9 +2,11 @@
 package param_parsing
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/spf13/viper"
 	"github.com/warrensbox/terraform-switcher/lib"
@@ -14,14 +16,42 @@ const tfSwitchTOMLFileName = ".tfswitch.toml"
 
 // getParamsTOML parses everything in the toml file, return required version and bin path
 func getParamsTOML(params Params) (Params, error) {
-	tomlPath := filepath.Join(params.TomlDir, tfSwitchTOMLFileName)
 	if tomlFileExists(params) {
+		return getParamsFromTOMLFile(params, filepath.Join(params.TomlDir, tfSwitchTOMLFileName))
+	}
+	return params, nil
+}
+
+// getParamsFromTOMLFile : parse parameters and products from the user's TOML file at tomlPath
+func getParamsFromTOMLFile(params Params, tomlPath string) (Params, error) {
+	return readTOMLFile(params, tomlPath, true)
+}
+
+// getParamsFromProjectTOMLFile : parse parameters from the project TOML file at tomlPath.
+// Unless the user trusts project files, keys deciding what gets installed where (`[products.<id>]` tables and keys of userOnly mappings)
+// are ignored, so that running tfswitch in a cloned repository cannot install binaries from arbitrary sources.
+func getParamsFromProjectTOMLFile(params Params, tomlPath string) (Params, error) {
+	return readTOMLFile(params, tomlPath, isProjectConfigTrusted(params))
+}
+
+// isProjectConfigTrusted : whether the user trusts project TOML files (see `trust-project-config`):
+// by the environment, otherwise by the user's TOML file
+func isProjectConfigTrusted(params Params) bool {
+	mapping := getParamMapping("TrustProjectConfig")
+	if value := os.Getenv(mapping.env); value != "" {
+		return parseEnvBool(mapping.env, value)
+	}
+	return params.TrustProjectConfig
+}
+
+// readTOMLFile : parse parameters and products from the TOML file at tomlPath,
+// disregarding the ones which are only accepted from trusted files unless trusted
+func readTOMLFile(params Params, tomlPath string, trusted bool) (Params, error) {
+	if lib.CheckFileExist(tomlPath) {
 		logger.Infof("Reading configuration from %q", tomlPath)
-		configfileName := lib.GetFileName(tfSwitchTOMLFileName)
 		viperParser := viper.New()
 		viperParser.SetConfigType("toml")
-		viperParser.SetConfigName(configfileName)
-		viperParser.AddConfigPath(params.TomlDir)
+		viperParser.SetConfigFile(tomlPath)
 
 		// Find and read the config file
 		if err := viperParser.ReadInConfig(); err != nil {
@@ -37,8 +67,7 @@ func getParamsTOML(params Params) (Params, error) {
 			toml := configKey.toml
 
 			if len(toml) == 0 {
-				logger.Errorf("Internal error: TOML key name is empty for parameter %q mapping, skipping assignment", param)
-				continue
+				continue // Not settable from TOML
 			}
 			if len(param) == 0 {
 				logger.Errorf("Internal error: parameter name is empty for TOML key %q mapping, skipping assignment", toml)
@@ -54,9 +83,23 @@ func getParamsTOML(params Params) (Params, error) {
 				continue
 			}
 
+			if configKey.userOnly && !trusted && viperParser.IsSet(toml) {
+				logger.Warnf("Ignoring %q key in project TOML config %q: only accepted from the user's configuration, unless it sets \"trust-project-config\"", toml, tomlPath)
+				continue
+			}
+
 			if viperParser.Get(toml) != nil {
 				configKeyValue := viperParser.Get(toml)
 
+				// Products may be declared as a list to switch several products at once
+				if productsList, ok := configKeyValue.([]any); ok && toml == "product" {
+					products := make([]string, 0, len(productsList))
+					for _, product := range productsList {
+						products = append(products, fmt.Sprint(product))
+					}
+					configKeyValue = strings.Join(products, ",")
+				}
+
 				if reflect.TypeOf(configKeyValue).Kind() != ptype {
 					logger.Warnf(
 						"TOML key %q is not a %s but a %s, skipping assignment of %q parameter from TOML",
@@ -66,7 +109,7 @@ func getParamsTOML(params Params) (Params, error) {
 				}
 
 				switch toml {
-				case "bin", "install":
+				case "bin", "chdir", "install":
 					envExpandedConfigKeyValue := os.ExpandEnv(configKeyValue.(string))
 					logger.Debugf(
 						"Expanded environment variables in %q TOML key value (if any): %q -> %q",
@@ -91,11 +134,70 @@ func getParamsTOML(params Params) (Params, error) {
 				}
 			}
 		}
+
+		if !trusted && viperParser.IsSet(tomlProductsKey) {
+			logger.Warnf("Ignoring %q table in project TOML config %q: only accepted from the user's configuration, unless it sets \"trust-project-config\"", tomlProductsKey, tomlPath)
+		} else if err := registerProductsTOML(viperParser, tomlPath); err != nil {
+			logger.Error(err)
+			return params, err
+		}
 	}
 	return params, nil
 }
 
 func tomlFileExists(params Params) bool {
+	if params.TomlDir == "" {
+		return false
+	}
 	tomlPath := filepath.Join(params.TomlDir, tfSwitchTOMLFileName)
 	return lib.CheckFileExist(tomlPath)
 }
+
+// getHomeTOMLFile : path of the user's TOML config, empty if it does not exist:
+// config.toml in the config directory (see lib.GetConfigDirectory), otherwise .tfswitch.toml in the home directory,
+// unless TFSWITCH_HOME relocates the configuration
+func getHomeTOMLFile(params Params) string {
+	if configDir := lib.GetConfigDirectory(); configDir != "" {
+		configPath := filepath.Join(configDir, lib.ConfigFileName)
+		if lib.IsRegularFile(configPath) {
+			if tomlFileExists(params) {
+				logger.Warnf("Ignoring %q in favor of %q", filepath.Join(params.TomlDir, tfSwitchTOMLFileName), configPath)
+			}
+			return configPath
+		}
+	}
+	if tfSwitchHome := lib.GetTFSwitchHome(); tfSwitchHome != "" {
+		if tomlFileExists(params) {
+			logger.Debugf("Ignoring %q as configuration is relocated by %s=%q", filepath.Join(params.TomlDir, tfSwitchTOMLFileName), lib.TFSwitchHomeEnvVar, tfSwitchHome)
+		}
+		return ""
+	}
+	if tomlFileExists(params) {
+		return filepath.Join(params.TomlDir, tfSwitchTOMLFileName)
+	}
+	return ""
+}
+
+// findProjectTOMLFile : path of the nearest TOML file in the working directory or its parents
+// (see getSearchDirs), empty if none found other than the one in the home directory
+func findProjectTOMLFile(params Params) string {
+	var homeTOMLPath string
+	if params.TomlDir != "" {
+		var err error
+		if homeTOMLPath, err = filepath.Abs(filepath.Join(params.TomlDir, tfSwitchTOMLFileName)); err != nil {
+			logger.Warnf("Could not resolve path of TOML config in home directory: %v", err)
+		}
+	}
+	for _, dir := range getSearchDirs(params) {
+		tomlPath := filepath.Join(dir, tfSwitchTOMLFileName)
+		if !lib.CheckFileExist(tomlPath) {
+			continue
+		}
+		if absPath, err := filepath.Abs(tomlPath); err == nil && absPath == homeTOMLPath {
+			logger.Tracef("No project TOML config found below home directory")
+			return ""
+		}
+		return tomlPath
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+// Suppressing linter warnings for this package:
+// - revive: FIXME: don't use an underscore in package name
+// - staticcheck: ST1005: error strings should not be capitalized (staticcheck)
+//
+//nolint:revive,staticcheck
+package param_parsing
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/viper"
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+const tomlProductsKey = "products"
+
+// Product declared in the `[products.<id>]` table of the TOML configuration file.
+// Fields mirror lib.ProductDetails.
+type tomlProduct struct {
+	Name             string             `mapstructure:"name"`
+	Mirror           string             `mapstructure:"mirror"`
+	DownloadMirror   string             `mapstructure:"download-mirror"`
+	VersionPrefix    string             `mapstructure:"version-prefix"`
+	ArchivePrefix    string             `mapstructure:"archive-prefix"`
+	Executable       string             `mapstructure:"executable"`
+	PublicKeyID      string             `mapstructure:"public-key-id"`
+	PublicKeyURLs    []string           `mapstructure:"public-key-urls"`
+	VersionsFormat   string             `mapstructure:"versions-format"`
+	ReleaseTagPrefix string             `mapstructure:"release-tag-prefix"`
+	SignatureSuffix  string             `mapstructure:"signature-suffix"`
+	FileExtensions   []string           `mapstructure:"file-extensions"`
+	VersionFiles     []string           `mapstructure:"version-files"`
+	ArchiveFormat    string             `mapstructure:"archive-format"`
+	Platforms        []tomlPlatformRule `mapstructure:"platforms"`
+}
+
+// Platform availability rule declared in the `[[products.<id>.platforms]]` array of tables.
+// Fields mirror lib.PlatformRule.
+type tomlPlatformRule struct {
+	OS           string `mapstructure:"os"`
+	Arch         string `mapstructure:"arch"`
+	Since        string `mapstructure:"since"`
+	FallbackArch string `mapstructure:"fallback-arch"`
+	Unavailable  bool   `mapstructure:"unavailable"`
+}
+
+// toCustomProduct : validate TOML product definition and fill in defaults
+func (t tomlProduct) toCustomProduct(id string) (lib.CustomProduct, error) {
+	var product lib.CustomProduct
+
+	if t.Mirror == "" {
+		return product, fmt.Errorf("%q key is required", "mirror")
+	}
+	if t.DownloadMirror == "" {
+		return product, fmt.Errorf("%q key is required", "download-mirror")
+	}
+	for _, url := range append([]string{t.Mirror, t.DownloadMirror}, t.PublicKeyURLs...) {
+		if err := lib.IsValidRemoteURL(url); err != nil {
+			return product, err
+		}
+	}
+	if len(t.PublicKeyURLs) == 0 {
+		return product, fmt.Errorf("%q key is required", "public-key-urls")
+	}
+	if t.PublicKeyID == "" {
+		return product, fmt.Errorf("%q key is required", "public-key-id")
+	}
+
+	versionsFormats := []string{lib.VersionsFormatTerraform, lib.VersionsFormatOpenTofu, lib.VersionsFormatGitHubReleases}
+	if t.VersionsFormat == "" {
+		t.VersionsFormat = lib.VersionsFormatTerraform
+	} else if !slices.Contains(versionsFormats, t.VersionsFormat) {
+		return product, fmt.Errorf("%q key must be one of %q, got %q", "versions-format", versionsFormats, t.VersionsFormat)
+	}
+
+	if _, err := lib.GetExtractor(t.ArchiveFormat); err != nil {
+		return product, fmt.Errorf("%q key: %v", "archive-format", err)
+	}
+
+	platformRules := make([]lib.PlatformRule, 0, len(t.Platforms))
+	for _, platform := range t.Platforms {
+		if platform.OS == "" {
+			return product, fmt.Errorf("%q key is required in %q", "os", "platforms")
+		}
+		if platform.Since != "" && !lib.IsValidVersionFormat(platform.Since) {
+			return product, fmt.Errorf("%q key in %q must be a version, got %q", "since", "platforms", platform.Since)
+		}
+		platformRules = append(platformRules, lib.PlatformRule(platform))
+	}
+
+	if t.Name == "" {
+		t.Name = id
+	}
+	if t.Executable == "" {
+		t.Executable = id
+	}
+	if t.VersionPrefix == "" {
+		t.VersionPrefix = id + "_"
+	}
+	if t.ArchivePrefix == "" {
+		t.ArchivePrefix = t.Executable + "_"
+	}
+	if len(t.VersionFiles) == 0 {
+		t.VersionFiles = []string{"." + id + "-version"}
+	}
+	for _, versionFile := range t.VersionFiles {
+		if versionFile == "" || filepath.Base(versionFile) != versionFile {
+			return product, fmt.Errorf("%q key must list file names, got %q", "version-files", versionFile)
+		}
+	}
+
+	versionsSource := lib.VersionsSourceJSON
+	if t.VersionsFormat == lib.VersionsFormatGitHubReleases {
+		versionsSource = lib.VersionsSourceGitHubReleases
+	}
+
+	product = lib.CustomProduct{
+		ProductDetails: lib.ProductDetails{
+			ID:                    id,
+			Name:                  t.Name,
+			DefaultMirror:         t.Mirror,
+			DefaultDownloadMirror: t.DownloadMirror,
+			VersionPrefix:         t.VersionPrefix,
+			ExecutableName:        t.Executable,
+			ArchivePrefix:         t.ArchivePrefix,
+			PublicKeyId:           t.PublicKeyID,
+			PublicKeyURLs:         t.PublicKeyURLs,
+			FileExtensions:        t.FileExtensions,
+			ArchiveFormat:         t.ArchiveFormat,
+			ReleaseTagPrefix:      t.ReleaseTagPrefix,
+			VersionsSource:        versionsSource,
+			PlatformRules:         platformRules,
+			VersionFileNames:      t.VersionFiles,
+		},
+		VersionsFormat:     t.VersionsFormat,
+		ShaSignatureSuffix: t.SignatureSuffix,
+	}
+	return product, nil
+}
+
+// registerProductsTOML : register products declared in the TOML configuration file
+func registerProductsTOML(viperParser *viper.Viper, tomlPath string) error {
+	if !viperParser.IsSet(tomlProductsKey) {
+		return nil
+	}
+
+	var tomlProducts map[string]tomlProduct
+	if err := viperParser.UnmarshalKey(tomlProductsKey, &tomlProducts); err != nil {
+		return fmt.Errorf("Could not parse %q table in %q: %v", tomlProductsKey, tomlPath, err)
+	}
+
+	// Register in a stable order to get reproducible product listings
+	productIDs := make([]string, 0, len(tomlProducts))
+	for id := range tomlProducts {
+		productIDs = append(productIDs, id)
+	}
+	sort.Strings(productIDs)
+
+	for _, id := range productIDs {
+		product, err := tomlProducts[id].toCustomProduct(id)
+		if err != nil {
+			return fmt.Errorf("Invalid %q product in %q: %v", id, tomlPath, err)
+		}
+		if err := lib.RegisterProduct(product); err != nil {
+			return fmt.Errorf("Could not register %q product from %q: %v", id, tomlPath, err)
+		}
+		logger.Debugf("Product %q (%s) from %q", id, product.GetName(), tomlPath)
+	}
+	return nil
+}
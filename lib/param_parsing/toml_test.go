@@ -3,8 +3,11 @@ package param_parsing
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/pborman/getopt"
 	"github.com/warrensbox/terraform-switcher/lib"
 )
 
@@ -126,3 +129,324 @@ func TestGetParamsTOML_error_in_file(t *testing.T) {
 		t.Errorf("Expected empty version string. Got: %q", params.Version)
 	}
 }
+
+func TestGetParamsTOML_products(t *testing.T) {
+	params, err := prepare("../../test-data/skip-integration-tests/test_tfswitchtoml_products")
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if expected := "waypoint"; params.Product != expected {
+		t.Errorf("Product not matching. Got %q, expected %q", params.Product, expected)
+	}
+
+	waypoint := lib.GetProductById("waypoint")
+	if waypoint == nil {
+		t.Fatal("Product \"waypoint\" from TOML was not registered")
+	}
+	if expected := "waypoint_"; waypoint.GetVersionPrefix() != expected {
+		t.Errorf("Default version prefix not matching. Got %q, expected %q", waypoint.GetVersionPrefix(), expected)
+	}
+	if expected := "waypoint_"; waypoint.GetArchivePrefix() != expected {
+		t.Errorf("Default archive prefix not matching. Got %q, expected %q", waypoint.GetArchivePrefix(), expected)
+	}
+	if expected := "https://releases.hashicorp.com/waypoint/0.11.4"; waypoint.GetArtifactUrl("", "0.11.4") != expected {
+		t.Errorf("Artifact URL not matching. Got %q, expected %q", waypoint.GetArtifactUrl("", "0.11.4"), expected)
+	}
+	if expected := "72D7468F.sig"; waypoint.GetShaSignatureSuffix() != expected {
+		t.Errorf("Signature suffix not matching. Got %q, expected %q", waypoint.GetShaSignatureSuffix(), expected)
+	}
+	if expected := []string{".waypoint-version"}; !slices.Equal(waypoint.GetVersionFileNames(), expected) {
+		t.Errorf("Default version files not matching. Got %q, expected %q", waypoint.GetVersionFileNames(), expected)
+	}
+
+	myTofu := lib.GetProductById("mytofu")
+	if myTofu == nil {
+		t.Fatal("Product \"mytofu\" from TOML was not registered")
+	}
+	if expected := "tofu"; myTofu.GetExecutableName() != expected {
+		t.Errorf("Executable name not matching. Got %q, expected %q", myTofu.GetExecutableName(), expected)
+	}
+	if expected := []string{".opentofu-version", ".mytofu-version"}; !slices.Equal(myTofu.GetVersionFileNames(), expected) {
+		t.Errorf("Version files not matching. Got %q, expected %q", myTofu.GetVersionFileNames(), expected)
+	}
+	if expected := "https://example.com/mytofu/releases/download/v1.7.2"; myTofu.GetArtifactUrl("", "1.7.2") != expected {
+		t.Errorf("Artifact URL not matching. Got %q, expected %q", myTofu.GetArtifactUrl("", "1.7.2"), expected)
+	}
+	versions, err := myTofu.GetVersionsFromJSON([]byte(`{"versions":[{"id":"1.7.2"},{"id":"1.7.1"}]}`))
+	if err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if len(versions) != 2 || versions[0] != "1.7.2" {
+		t.Errorf("Versions not matching. Got %q", versions)
+	}
+	if arch, _ := lib.ResolvePlatformArch(myTofu, "1.6.2", "darwin", "arm64"); arch != "amd64" {
+		t.Errorf("Platform fallback arch not matching. Got %q, expected %q", arch, "amd64")
+	}
+	if _, err := lib.ResolvePlatformArch(myTofu, "1.7.2", "freebsd", "amd64"); err == nil {
+		t.Error("Expected error for version not available on platform. Got nil")
+	}
+
+	// Reading the same configuration again must replace, not duplicate, the products
+	productsCount := len(lib.GetAllProducts())
+	if _, err = prepare("../../test-data/skip-integration-tests/test_tfswitchtoml_products"); err != nil {
+		t.Fatalf("Got error: %v", err)
+	}
+	if len(lib.GetAllProducts()) != productsCount {
+		t.Errorf("Products got duplicated on re-read. Got %d, expected %d", len(lib.GetAllProducts()), productsCount)
+	}
+}
+
+func TestTomlProduct_toCustomProduct_invalid(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	valid := tomlProduct{
+		Mirror:         "https://example.com/index.json",
+		DownloadMirror: "https://example.com",
+		PublicKeyID:    "ABCDEF",
+		PublicKeyURLs:  []string{"https://example.com/key.asc"},
+	}
+	if _, err := valid.toCustomProduct("example"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	noMirror := valid
+	noMirror.Mirror = ""
+	invalidURL := valid
+	invalidURL.DownloadMirror = "ftp://example.com"
+	noPublicKey := valid
+	noPublicKey.PublicKeyURLs = nil
+	invalidFormat := valid
+	invalidFormat.VersionsFormat = "yaml"
+	invalidArchiveFormat := valid
+	invalidArchiveFormat.ArchiveFormat = "rar"
+	noPlatformOS := valid
+	noPlatformOS.Platforms = []tomlPlatformRule{{Arch: "arm64", Since: "1.0.0"}}
+	invalidPlatformSince := valid
+	invalidPlatformSince.Platforms = []tomlPlatformRule{{OS: "darwin", Since: "latest"}}
+	invalidVersionFile := valid
+	invalidVersionFile.VersionFiles = []string{"../.example-version"}
+
+	for name, definition := range map[string]tomlProduct{
+		"no mirror":              noMirror,
+		"invalid URL":            invalidURL,
+		"no public key URLs":     noPublicKey,
+		"invalid format":         invalidFormat,
+		"invalid archive format": invalidArchiveFormat,
+		"no platform OS":         noPlatformOS,
+		"invalid platform since": invalidPlatformSince,
+		"invalid version file":   invalidVersionFile,
+	} {
+		if _, err := definition.toCustomProduct("example"); err == nil {
+			t.Errorf("Expected error for product definition with %s. Got nil", name)
+		}
+	}
+}
+
+func TestGetParameters_project_toml(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	homeDir := t.TempDir()
+	repoDir, moduleDir := createTestRepo(t)
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+arch = "arm64"
+bin = "/home/user/bin/terraform"
+default-version = "1.5.0"
+mirror = "https://mirror.example.com/terraform/index.json"
+`)
+	writeTestFile(t, repoDir, tfSwitchTOMLFileName, `
+default-version = "1.6.0"
+mirror = "https://mirror.internal.example.com/terraform/index.json"
+mirror-download = "https://mirror.internal.example.com/terraform"
+`)
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + moduleDir, "--product=terraform"}
+	params := initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+
+	for name, values := range map[string][2]string{
+		"Arch":              {"arm64", params.Arch},
+		"CustomBinaryPath":  {"/home/user/bin/terraform", params.CustomBinaryPath},
+		"DefaultVersion":    {"1.6.0", params.DefaultVersion},
+		"MirrorURL":         {"https://mirror.internal.example.com/terraform/index.json", params.MirrorURL},
+		"MirrorDownloadURL": {"https://mirror.internal.example.com/terraform", params.MirrorDownloadURL},
+	} {
+		if values[0] != values[1] {
+			t.Errorf("%s Param was not as expected. Actual: %q, Expected: %q", name, values[1], values[0])
+		}
+	}
+
+	// Project configuration is not searched beyond the boundary
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + moduleDir, "--product=terraform", "--search-boundary=none"}
+	params = initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := "1.5.0"; params.DefaultVersion != expected {
+		t.Errorf("DefaultVersion Param was not as expected. Actual: %q, Expected: %q", params.DefaultVersion, expected)
+	}
+}
+
+func TestGetParameters_project_toml_untrusted(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	t.Setenv("TF_TRUST_PROJECT_CONFIG", "")
+	homeDir := t.TempDir()
+	repoDir, moduleDir := createTestRepo(t)
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+bin = "/home/user/bin/terraform"
+`)
+	writeTestFile(t, repoDir, tfSwitchTOMLFileName, `
+bin = "/tmp/evil/terraform"
+install = "/tmp/evil"
+trust-project-config = true
+default-version = "1.6.0"
+
+[products.projecttool]
+name = "Project tool"
+executable-name = "terraform"
+mirror = "https://evil.example.com/index.json"
+download-mirror = "https://evil.example.com/releases"
+public-key-id = "DEADBEEF"
+public-key-urls = ["https://evil.example.com/key.asc"]
+`)
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + moduleDir, "--product=terraform"}
+	params := initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := "/home/user/bin/terraform"; params.CustomBinaryPath != expected {
+		t.Errorf("CustomBinaryPath Param was not as expected. Actual: %q, Expected: %q", params.CustomBinaryPath, expected)
+	}
+	if params.InstallPath == "/tmp/evil" {
+		t.Errorf("InstallPath Param was set from project TOML config: %q", params.InstallPath)
+	}
+	if params.TrustProjectConfig {
+		t.Error("TrustProjectConfig Param was set from project TOML config")
+	}
+	if expected := "1.6.0"; params.DefaultVersion != expected {
+		t.Errorf("DefaultVersion Param was not as expected. Actual: %q, Expected: %q", params.DefaultVersion, expected)
+	}
+	if lib.GetProductById("projecttool") != nil {
+		t.Error("Product \"projecttool\" from project TOML config was registered")
+	}
+
+	t.Log("Trusted by the user's configuration")
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+trust-project-config = true
+`)
+	getopt.CommandLine = getopt.New()
+	params = initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := "/tmp/evil/terraform"; params.CustomBinaryPath != expected {
+		t.Errorf("CustomBinaryPath Param was not as expected. Actual: %q, Expected: %q", params.CustomBinaryPath, expected)
+	}
+	if expected := "/tmp/evil"; params.InstallPath != expected {
+		t.Errorf("InstallPath Param was not as expected. Actual: %q, Expected: %q", params.InstallPath, expected)
+	}
+	if lib.GetProductById("projecttool") == nil {
+		t.Error("Product \"projecttool\" from trusted project TOML config was not registered")
+	}
+}
+
+func TestGetParameters_tfswitch_home(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	homeDir := t.TempDir()
+	tfSwitchHome := t.TempDir()
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+default-version = "1.5.0"
+`)
+	t.Setenv("TFSWITCH_HOME", tfSwitchHome)
+
+	// Configuration in the home directory is disregarded once relocated
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + t.TempDir(), "--product=terraform"}
+	params := initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := ""; params.DefaultVersion != expected {
+		t.Errorf("DefaultVersion Param was not as expected. Actual: %q, Expected: %q", params.DefaultVersion, expected)
+	}
+	if expected := tfSwitchHome; params.InstallPath != expected {
+		t.Errorf("InstallPath Param was not as expected. Actual: %q, Expected: %q", params.InstallPath, expected)
+	}
+
+	writeTestFile(t, tfSwitchHome, lib.ConfigFileName, `
+default-version = "1.6.0"
+`)
+	getopt.CommandLine = getopt.New()
+	params = initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := "1.6.0"; params.DefaultVersion != expected {
+		t.Errorf("DefaultVersion Param was not as expected. Actual: %q, Expected: %q", params.DefaultVersion, expected)
+	}
+}
+
+func TestGetParameters_xdg_config(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Cleanup(func() {
+		getopt.CommandLine = getopt.New()
+	})
+	homeDir := t.TempDir()
+	xdgConfigHome := t.TempDir()
+	if err := os.Mkdir(filepath.Join(xdgConfigHome, "tfswitch"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+default-version = "1.5.0"
+`)
+	writeTestFile(t, filepath.Join(xdgConfigHome, "tfswitch"), lib.ConfigFileName, `
+default-version = "1.6.0"
+`)
+	t.Setenv("TFSWITCH_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+
+	getopt.CommandLine = getopt.New()
+	os.Args = []string{"cmd", "--chdir=" + t.TempDir(), "--product=terraform"}
+	params := initParams(Params{})
+	params.TomlDir = homeDir
+	params = populateParams(params)
+	if expected := "1.6.0"; params.DefaultVersion != expected {
+		t.Errorf("DefaultVersion Param was not as expected. Actual: %q, Expected: %q", params.DefaultVersion, expected)
+	}
+}
+
+func TestGetParamsTOML_all_options(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	tomlDir := t.TempDir()
+	t.Setenv("CHDIR_FROM_ENV", "/chdir_from_toml")
+	writeTestFile(t, tomlDir, tfSwitchTOMLFileName, `
+chdir = "$CHDIR_FROM_ENV"
+dry-run = true
+latest-stable = "1.9"
+match-version-requirement = "1.9.5"
+show-required = true
+strict-config = true
+`)
+	params, err := getParamsFromTOMLFile(initParams(Params{}), filepath.Join(tomlDir, tfSwitchTOMLFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "/chdir_from_toml"; params.ChDirPath != expected {
+		t.Errorf("ChDirPath Param was not as expected. Actual: %q, Expected: %q", params.ChDirPath, expected)
+	}
+	if expected := "1.9"; params.LatestStable != expected {
+		t.Errorf("LatestStable Param was not as expected. Actual: %q, Expected: %q", params.LatestStable, expected)
+	}
+	if expected := "1.9.5"; params.MatchVersionRequirement != expected {
+		t.Errorf("MatchVersionRequirement Param was not as expected. Actual: %q, Expected: %q", params.MatchVersionRequirement, expected)
+	}
+	if !params.DryRun || !params.ShowRequiredFlag || !params.StrictConfig {
+		t.Errorf("Boolean Params from TOML are not all set: %+v", params)
+	}
+}
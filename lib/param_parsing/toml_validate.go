@@ -0,0 +1,535 @@
+// Suppressing linter warnings for this package:
+// - revive: FIXME: don't use an underscore in package name
+// - staticcheck: ST1005: error strings should not be capitalized (staticcheck)
+//
+//nolint:revive,staticcheck
+package param_parsing
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pborman/getopt"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+const (
+	configCommandName     = "config"
+	configValidateCommand = "validate"
+)
+
+// Keys required in each of the `[products.<id>]` tables
+var tomlProductRequiredKeys = []string{"mirror", "download-mirror", "public-key-id", "public-key-urls"}
+
+// Kinds of values in the TOML configuration file
+const (
+	tomlSchemaString          = "string"
+	tomlSchemaBool            = "boolean"
+	tomlSchemaStrings         = "array of strings"
+	tomlSchemaStringOrStrings = "string or array of strings"
+	tomlSchemaTable           = "table"
+	tomlSchemaTables          = "array of tables"
+)
+
+// tomlSchema : expected value of a key in the TOML configuration file
+type tomlSchema struct {
+	kind     string
+	fields   map[string]*tomlSchema // Keys of the table, or of each table of the array of tables
+	values   *tomlSchema            // Values of the table with arbitrary keys (e.g. products by ID)
+	validate func(string) error     // Additional check of the string values
+}
+
+// configIssue : problem found in the TOML configuration file
+type configIssue struct {
+	File    string
+	Line    int
+	Key     string
+	Message string
+}
+
+func (i configIssue) String() string {
+	if i.Key == "" {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, i.Key, i.Message)
+}
+
+// tomlValidator : validates TOML configuration files against the schema. Products declared in one
+// file may be referred to in the files validated after it (e.g. home directory, then project file)
+type tomlValidator struct {
+	declaredProducts   []string
+	trustProjectConfig bool // Keys of project files are applied as in the user's file (see isProjectConfigTrusted)
+}
+
+// tomlProductRef : product ID referred to by `product` key, checked once all products of the file are declared
+type tomlProductRef struct {
+	id   string
+	line int
+}
+
+// tomlValidation : state of validation of a single file
+type tomlValidation struct {
+	parser      unstable.Parser
+	filePath    string
+	issues      []configIssue
+	productRefs []tomlProductRef
+	productKeys map[string][]string // Keys set in each of the declared products
+	productLine map[string]int      // Line where each of the products is declared
+	currentLine int                 // Line of the value being validated
+	untrusted   bool                // Project file, whose user-only keys are ignored (see getParamsFromProjectTOMLFile)
+	setsTrust   bool                // Sets `trust-project-config` to true
+}
+
+// schemaFromStruct : schema of the table decoded into the struct type, from its `mapstructure` tags
+func schemaFromStruct(structType reflect.Type) *tomlSchema {
+	schema := &tomlSchema{kind: tomlSchemaTable, fields: map[string]*tomlSchema{}}
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+		switch {
+		case field.Type.Kind() == reflect.String:
+			schema.fields[key] = &tomlSchema{kind: tomlSchemaString}
+		case field.Type.Kind() == reflect.Bool:
+			schema.fields[key] = &tomlSchema{kind: tomlSchemaBool}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String:
+			schema.fields[key] = &tomlSchema{kind: tomlSchemaStrings}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			schema.fields[key] = &tomlSchema{kind: tomlSchemaTables, fields: schemaFromStruct(field.Type.Elem()).fields}
+		}
+	}
+	return schema
+}
+
+// newTOMLSchema : schema of the TOML configuration file: keys of paramMappings and `[products.<id>]` tables
+func (v *tomlValidation) newTOMLSchema() *tomlSchema {
+	schema := &tomlSchema{kind: tomlSchemaTable, fields: map[string]*tomlSchema{}}
+	for _, mapping := range paramMappings {
+		if mapping.toml == "" {
+			continue
+		}
+		kind := tomlSchemaString
+		if mapping.ptype == reflect.Bool {
+			kind = tomlSchemaBool
+		}
+		schema.fields[mapping.toml] = &tomlSchema{kind: kind}
+	}
+
+	isValidURL := func(value string) error {
+		return lib.IsValidRemoteURL(value)
+	}
+	schema.fields["mirror"].validate = isValidURL
+	schema.fields["mirror-download"].validate = isValidURL
+	schema.fields["bin"].validate = func(value string) error {
+		binDir := filepath.Dir(os.ExpandEnv(value))
+		if !lib.CheckDirExist(binDir) {
+			return fmt.Errorf("directory %q does not exist", binDir)
+		}
+		return nil
+	}
+	schema.fields["trust-project-config"].validate = func(value string) error {
+		v.setsTrust = value == "true"
+		return nil
+	}
+	schema.fields["product"].kind = tomlSchemaStringOrStrings // Several products may be switched at once
+	schema.fields["product"].validate = func(value string) error {
+		for _, id := range splitProducts(value) {
+			v.productRefs = append(v.productRefs, tomlProductRef{id: id, line: v.currentLine})
+		}
+		return nil
+	}
+
+	product := schemaFromStruct(reflect.TypeOf(tomlProduct{}))
+	for _, key := range []string{"mirror", "download-mirror", "public-key-urls"} {
+		product.fields[key].validate = isValidURL
+	}
+	schema.fields[tomlProductsKey] = &tomlSchema{kind: tomlSchemaTable, values: product}
+	return schema
+}
+
+// validate : check the TOML configuration file for syntax errors, unknown keys, values of wrong type
+// and invalid values (unknown products, malformed URLs, missing directories).
+// Keys which are only accepted from the user's file are reported as ignored in untrusted project files.
+func (t *tomlValidator) validate(filePath string, userFile bool) ([]configIssue, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read %q: %v", filePath, err)
+	}
+	content = content[:len(content):len(content)] // Error highlights must be subslices of the content
+
+	v := &tomlValidation{filePath: filePath, productKeys: map[string][]string{}, productLine: map[string]int{}, untrusted: !userFile && !t.trustProjectConfig}
+	root := v.newTOMLSchema()
+	current, currentPath := root, []string(nil)
+	v.parser.Reset(content)
+	for v.parser.NextExpression() {
+		expr := v.parser.Expression()
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			line := v.line(expr.Child())
+			path := keyParts(expr.Key())
+			current, currentPath = nil, path
+			if v.isIgnored(path, line) {
+				continue
+			}
+			v.declareProduct(path, line)
+			schema := v.resolve(root, nil, path, line)
+			switch {
+			case schema == nil:
+			case expr.Kind == unstable.ArrayTable && schema.kind != tomlSchemaTables:
+				v.addIssue(line, strings.Join(path, "."), fmt.Sprintf("must be a %s, got array of tables", schema.kind))
+			case expr.Kind == unstable.Table && schema.kind != tomlSchemaTable:
+				v.addIssue(line, strings.Join(path, "."), fmt.Sprintf("must be a %s, got table", schema.kind))
+			default:
+				current = &tomlSchema{kind: tomlSchemaTable, fields: schema.fields, values: schema.values}
+			}
+		case unstable.KeyValue:
+			if current != nil { // Keys of invalid tables are not validated
+				v.checkKeyValue(current, currentPath, expr)
+			}
+		}
+	}
+
+	if err := v.parser.Error(); err != nil {
+		line := 0
+		var parserErr *unstable.ParserError
+		if errors.As(err, &parserErr) {
+			line = v.parser.Shape(v.parser.Range(parserErr.Highlight)).Start.Line
+		}
+		v.addIssue(line, "", fmt.Sprintf("syntax error: %v", err))
+		return v.issues, nil
+	}
+
+	if userFile && v.setsTrust {
+		t.trustProjectConfig = isProjectConfigTrusted(Params{TrustProjectConfig: true}) // Unless disabled by the environment
+	}
+
+	// Products are checked once all of them are declared, as they may be referred to before their table
+	t.declaredProducts = append(t.declaredProducts, slices.Collect(maps.Keys(v.productLine))...)
+	for _, ref := range v.productRefs {
+		if lib.GetProductById(ref.id) == nil && !slices.Contains(t.declaredProducts, ref.id) {
+			var productIds []string
+			for _, product := range lib.GetAllProducts() {
+				productIds = append(productIds, product.GetId())
+			}
+			v.addIssue(ref.line, "product", fmt.Sprintf("unknown product %q, expected one of: %s (or a product declared in %q table)", ref.id, strings.Join(append(productIds, t.declaredProducts...), ", "), tomlProductsKey))
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(v.productLine)) {
+		for _, key := range tomlProductRequiredKeys {
+			if !slices.Contains(v.productKeys[id], key) {
+				v.addIssue(v.productLine[id], fmt.Sprintf("%s.%s", tomlProductsKey, id), fmt.Sprintf("%q key is required", key))
+			}
+		}
+	}
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		return v.issues[i].Line < v.issues[j].Line
+	})
+	return v.issues, nil
+}
+
+// checkKeyValue : check key-value pair (possibly with a dotted key) of the table at tablePath
+func (v *tomlValidation) checkKeyValue(table *tomlSchema, tablePath []string, expr *unstable.Node) {
+	keyIterator := expr.Key()
+	line := v.line(keyIterator.Node())
+	relPath := keyParts(keyIterator)
+	path := slices.Concat(tablePath, relPath)
+	if v.isIgnored(path, line) {
+		return
+	}
+	v.declareProduct(path, line)
+	if len(path) == 3 && path[0] == tomlProductsKey {
+		v.productKeys[path[1]] = append(v.productKeys[path[1]], path[2])
+	}
+
+	schema := v.resolve(table, tablePath, relPath, line)
+	if schema != nil {
+		v.checkValue(schema, path, expr.Value(), line)
+	}
+}
+
+// checkValue : check the value against the schema of the key at path
+func (v *tomlValidation) checkValue(schema *tomlSchema, path []string, value *unstable.Node, line int) {
+	key := strings.Join(path, ".")
+	typeIssue := func() {
+		v.addIssue(line, key, fmt.Sprintf("must be a %s, got %s", schema.kind, strings.ToLower(value.Kind.String())))
+	}
+	validate := func(node *unstable.Node) {
+		if schema.validate == nil {
+			return
+		}
+		v.currentLine = line
+		if err := schema.validate(string(node.Data)); err != nil {
+			v.addIssue(line, key, err.Error())
+		}
+	}
+	isStrings := func() bool {
+		if value.Kind != unstable.Array {
+			return false
+		}
+		elements := value.Children()
+		for elements.Next() {
+			if elements.Node().Kind != unstable.String {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch schema.kind {
+	case tomlSchemaString, tomlSchemaBool:
+		if (schema.kind == tomlSchemaString && value.Kind != unstable.String) || (schema.kind == tomlSchemaBool && value.Kind != unstable.Bool) {
+			typeIssue()
+			return
+		}
+		validate(value)
+	case tomlSchemaStrings, tomlSchemaStringOrStrings:
+		if schema.kind == tomlSchemaStringOrStrings && value.Kind == unstable.String {
+			validate(value)
+			return
+		}
+		if !isStrings() {
+			typeIssue()
+			return
+		}
+		elements := value.Children()
+		for elements.Next() {
+			validate(elements.Node())
+		}
+	case tomlSchemaTable:
+		if value.Kind != unstable.InlineTable {
+			typeIssue()
+			return
+		}
+		v.checkInlineTable(schema, path, value)
+	case tomlSchemaTables:
+		if value.Kind != unstable.Array {
+			typeIssue()
+			return
+		}
+		elements := value.Children()
+		for elements.Next() {
+			if elements.Node().Kind != unstable.InlineTable {
+				typeIssue()
+				return
+			}
+			v.checkInlineTable(&tomlSchema{kind: tomlSchemaTable, fields: schema.fields}, path, elements.Node())
+		}
+	}
+}
+
+// checkInlineTable : check key-value pairs of the inline table at path
+func (v *tomlValidation) checkInlineTable(schema *tomlSchema, path []string, table *unstable.Node) {
+	keyValues := table.Children()
+	for keyValues.Next() {
+		v.checkKeyValue(schema, path, keyValues.Node())
+	}
+}
+
+// resolve : schema of the key path relative to the table at tablePath, nil if the key is unknown
+func (v *tomlValidation) resolve(schema *tomlSchema, tablePath []string, relPath []string, line int) *tomlSchema {
+	path := slices.Concat(tablePath, relPath)
+	for i, part := range relPath {
+		i += len(tablePath)
+		switch {
+		case schema.values != nil:
+			schema = schema.values
+		case schema.fields != nil:
+			next, found := schema.fields[part]
+			if !found {
+				message := "unknown key"
+				if suggestion := suggestKey(part, schema.fields); suggestion != "" {
+					message = fmt.Sprintf("unknown key, did you mean %q?", suggestion)
+				}
+				v.addIssue(line, strings.Join(path[:i+1], "."), message)
+				return nil
+			}
+			schema = next
+		default:
+			v.addIssue(line, strings.Join(path[:i], "."), fmt.Sprintf("must be a %s, got table", schema.kind))
+			return nil
+		}
+	}
+	return schema
+}
+
+// isIgnored : whether the key path is ignored, as the file is untrusted (reported as an issue)
+func (v *tomlValidation) isIgnored(path []string, line int) bool {
+	if !v.untrusted || len(path) == 0 {
+		return false
+	}
+	mapping := getTOMLMapping(path[0])
+	if path[0] != tomlProductsKey && (mapping == nil || !mapping.userOnly) {
+		return false
+	}
+	v.addIssue(line, strings.Join(path, "."), "ignored in project config, unless \"trust-project-config\" is set in the user's configuration")
+	return true
+}
+
+// declareProduct : record declaration of the product if the key path is within `products` table
+func (v *tomlValidation) declareProduct(path []string, line int) {
+	if len(path) < 2 || path[0] != tomlProductsKey {
+		return
+	}
+	if _, found := v.productLine[path[1]]; !found {
+		v.productLine[path[1]] = line
+	}
+}
+
+func (v *tomlValidation) addIssue(line int, key string, message string) {
+	v.issues = append(v.issues, configIssue{File: v.filePath, Line: line, Key: key, Message: message})
+}
+
+// line : line of the node in the file
+func (v *tomlValidation) line(node *unstable.Node) int {
+	if node == nil {
+		return 0
+	}
+	return v.parser.Shape(node.Raw).Start.Line
+}
+
+// keyParts : parts of the (dotted) key
+func keyParts(keyIterator unstable.Iterator) []string {
+	var parts []string
+	for keyIterator.Next() {
+		parts = append(parts, string(keyIterator.Node().Data))
+	}
+	return parts
+}
+
+// suggestKey : known key closest to the unknown one, if it looks like a typo of it
+func suggestKey(key string, fields map[string]*tomlSchema) string {
+	const maxDistance = 2
+	normalizedKey := strings.ReplaceAll(strings.ToLower(key), "_", "-")
+	suggestion, bestDistance := "", maxDistance+1
+	for _, known := range slices.Sorted(maps.Keys(fields)) {
+		if distance := levenshteinDistance(normalizedKey, known); distance < bestDistance {
+			suggestion, bestDistance = known, distance
+		}
+	}
+	return suggestion
+}
+
+// levenshteinDistance : number of single-character edits to turn one string into the other
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// getTOMLFiles : TOML configuration files used by the run: home directory one, then the project one
+func getTOMLFiles(params Params) []string {
+	var tomlPaths []string
+	for _, findTOMLFile := range []func(Params) string{getHomeTOMLFile, findProjectTOMLFile} {
+		if tomlPath := findTOMLFile(params); tomlPath != "" {
+			tomlPaths = append(tomlPaths, tomlPath)
+		}
+	}
+	return tomlPaths
+}
+
+// isSamePath : whether both paths refer to the same file
+func isSamePath(path string, otherPath string) bool {
+	if path == "" || otherPath == "" {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	otherAbsPath, err := filepath.Abs(otherPath)
+	return err == nil && absPath == otherAbsPath
+}
+
+// isStrictConfig : whether strict mode is enabled for the next TOML configuration file:
+// by the command line, otherwise by the environment, otherwise by the configuration files read so far
+func isStrictConfig(params Params) bool {
+	mapping := getParamMapping("StrictConfig")
+	if opt := getopt.Lookup(mapping.flag); opt != nil && opt.Seen() {
+		return true
+	}
+	if value := os.Getenv(mapping.env); value != "" {
+		return parseEnvBool(mapping.env, value)
+	}
+	return params.StrictConfig
+}
+
+// checkTOMLFileStrict : fail on any issue found in the TOML configuration file (see `--strict-config`)
+func (t *tomlValidator) checkTOMLFileStrict(tomlPath string, userFile bool) error {
+	issues, err := t.validate(tomlPath, userFile)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		logger.Error(issue.String())
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("Found %d issue(s) in %q", len(issues), tomlPath)
+	}
+	return nil
+}
+
+// RunConfigCommand : run `tfswitch config <command>`. Only `validate [FILE...]` is supported,
+// which validates the given TOML configuration files, or the ones used by the run if none given
+func RunConfigCommand(w io.Writer, params Params) error {
+	args := params.ConfigCommand
+	if len(args) < 2 || args[1] != configValidateCommand {
+		return fmt.Errorf("Unknown %q command. Usage: tfswitch %s %s [FILE...]", strings.Join(args, " "), configCommandName, configValidateCommand)
+	}
+
+	tomlPaths := args[2:]
+	if len(tomlPaths) == 0 {
+		tomlPaths = getTOMLFiles(params)
+	}
+	if len(tomlPaths) == 0 {
+		_, err := fmt.Fprintf(w, "No %s files found\n", tfSwitchTOMLFileName)
+		return err
+	}
+
+	homeTOMLPath := getHomeTOMLFile(params)
+	// Configuration files are not read for `config` command: trust set in the user's file is learned when validating it
+	validator := &tomlValidator{trustProjectConfig: isProjectConfigTrusted(params)}
+	var issuesCount int
+	for _, tomlPath := range tomlPaths {
+		issues, err := validator.validate(tomlPath, isSamePath(tomlPath, homeTOMLPath))
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(w, issue.String())
+		}
+		if len(issues) == 0 {
+			fmt.Fprintf(w, "%s: OK\n", tomlPath)
+		}
+		issuesCount += len(issues)
+	}
+	if issuesCount > 0 {
+		return fmt.Errorf("Found %d issue(s) in configuration", issuesCount)
+	}
+	return nil
+}
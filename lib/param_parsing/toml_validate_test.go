@@ -0,0 +1,177 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestTOMLValidator_validate(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, tfSwitchTOMLFileName, `
+instal = "/tmp"
+bin = "`+filepath.Join(tempDir, "nonexistent", "terraform")+`"
+no-color = "yes"
+product = ["terraform", "mytofu", "nope"]
+mirror = "ftp://example.com"
+
+[products.mytofu]
+mirror = "https://example.com/api.json"
+download-mirror = "https://example.com/download"
+public-key-id = "0C0AF313E5FD9F80"
+public-key-urls = ["not a URL"]
+
+[[products.mytofu.platforms]]
+os = "linux"
+since = 1
+
+[products.other]
+mirror = "https://example.com/other.json"
+`)
+
+	issues, err := (&tomlValidator{}).validate(filepath.Join(tempDir, tfSwitchTOMLFileName), true)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	expected := []struct {
+		line int
+		key  string
+	}{
+		{2, "instal"},
+		{3, "bin"},
+		{4, "no-color"},
+		{5, "product"},
+		{6, "mirror"},
+		{12, "products.mytofu.public-key-urls"},
+		{16, "products.mytofu.platforms.since"},
+		{18, "products.other"}, // download-mirror
+		{18, "products.other"}, // public-key-id
+		{18, "products.other"}, // public-key-urls
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Unexpected number of issues. Expected: %d, Actual: %d (%v)", len(expected), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue.Line != expected[i].line || issue.Key != expected[i].key {
+			t.Errorf("Unexpected issue. Expected: %s:%d, Actual: %s", expected[i].key, expected[i].line, issue)
+		}
+	}
+	if !strings.Contains(issues[0].Message, `did you mean "install"`) {
+		t.Errorf("Expected suggestion of known key, got %q", issues[0].Message)
+	}
+	if !strings.Contains(issues[3].Message, `"nope"`) {
+		t.Errorf("Expected only undeclared product to be reported, got %q", issues[3].Message)
+	}
+
+	writeTestFile(t, tempDir, "invalid.toml", "version = \"1.0\nbin = 1\n")
+	issues, err = (&tomlValidator{}).validate(filepath.Join(tempDir, "invalid.toml"), true)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Line != 1 || !strings.Contains(issues[0].Message, "syntax error") {
+		t.Errorf("Expected syntax error at line 1, got %v", issues)
+	}
+}
+
+func TestTOMLValidator_validate_project(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	t.Setenv("TF_TRUST_PROJECT_CONFIG", "")
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, tfSwitchTOMLFileName, `
+bin = "`+filepath.Join(tempDir, "terraform")+`"
+install = "`+tempDir+`"
+product = "projecttofu"
+
+[products.projecttofu]
+mirror = "https://example.com/api.json"
+download-mirror = "https://example.com/download"
+public-key-id = "0C0AF313E5FD9F80"
+public-key-urls = ["https://example.com/key.asc"]
+`)
+	tomlPath := filepath.Join(tempDir, tfSwitchTOMLFileName)
+
+	issues, err := (&tomlValidator{}).validate(tomlPath, false)
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	expected := []struct {
+		line int
+		key  string
+	}{
+		{2, "bin"},
+		{3, "install"},
+		{4, "product"}, // Product of the ignored table is unknown
+		{6, "products.projecttofu"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Unexpected number of issues. Expected: %d, Actual: %d (%v)", len(expected), len(issues), issues)
+	}
+	for i, issue := range issues {
+		if issue.Line != expected[i].line || issue.Key != expected[i].key {
+			t.Errorf("Unexpected issue. Expected: %s:%d, Actual: %s", expected[i].key, expected[i].line, issue)
+		}
+	}
+	if !strings.Contains(issues[0].Message, "ignored in project config") {
+		t.Errorf("Expected key to be reported as ignored, got %q", issues[0].Message)
+	}
+
+	if issues, err = (&tomlValidator{trustProjectConfig: true}).validate(tomlPath, false); err != nil || len(issues) != 0 {
+		t.Errorf("Expected no issues in trusted project config. Got: %v (error: %v)", issues, err)
+	}
+}
+
+func TestRunConfigCommand(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	homeDir := t.TempDir()
+	repoDir, moduleDir := createTestRepo(t)
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, `
+[products.validatedtofu]
+mirror = "https://example.com/api.json"
+download-mirror = "https://example.com/download"
+public-key-id = "0C0AF313E5FD9F80"
+public-key-urls = ["https://example.com/key.asc"]
+`)
+	// Product declared in the home directory configuration
+	writeTestFile(t, repoDir, tfSwitchTOMLFileName, `product = "validatedtofu"`)
+
+	params := Params{ChDirPath: moduleDir, TomlDir: homeDir, SearchBoundary: SearchBoundaryGit}
+	var output bytes.Buffer
+	params.ConfigCommand = []string{configCommandName, configValidateCommand}
+	if err := RunConfigCommand(&output, params); err != nil {
+		t.Errorf("Expected no error. Got: %v\n%s", err, output.String())
+	}
+	if strings.Count(output.String(), ": OK") != 2 {
+		t.Errorf("Expected both files to be valid, got:\n%s", output.String())
+	}
+
+	output.Reset()
+	params.ConfigCommand = []string{configCommandName, configValidateCommand, filepath.Join(repoDir, tfSwitchTOMLFileName)}
+	if err := RunConfigCommand(&output, params); err == nil {
+		t.Errorf("Expected error for product not declared in the validated file. Got nil\n%s", output.String())
+	}
+
+	params.ConfigCommand = []string{configCommandName, "unknown"}
+	if err := RunConfigCommand(&output, params); err == nil {
+		t.Error("Expected error for unknown command. Got nil")
+	}
+
+	// User-only keys of the project file
+	t.Setenv("TF_TRUST_PROJECT_CONFIG", "")
+	writeTestFile(t, repoDir, tfSwitchTOMLFileName, "install = \""+repoDir+"\"\n")
+	output.Reset()
+	params.ConfigCommand = []string{configCommandName, configValidateCommand}
+	if err := RunConfigCommand(&output, params); err == nil || !strings.Contains(output.String(), "install: ignored in project config") {
+		t.Errorf("Expected key of untrusted project config to be reported as ignored. Got: %v\n%s", err, output.String())
+	}
+
+	writeTestFile(t, homeDir, tfSwitchTOMLFileName, "trust-project-config = true\n")
+	output.Reset()
+	if err := RunConfigCommand(&output, params); err != nil {
+		t.Errorf("Expected no error for trusted project config. Got: %v\n%s", err, output.String())
+	}
+}
@@ -0,0 +1,79 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+const toolVersionsFileName = ".tool-versions"
+
+// Names of asdf/mise plugins differing from product IDs
+var toolVersionsPluginNames = map[string]string{
+	"tofu": openTofuProductId,
+}
+
+// getToolVersionsProductId : product ID of the asdf/mise plugin, empty if the plugin is not a known product
+//
+//nolint:revive // FIXME: var-naming: func getToolVersionsProductId should be getToolVersionsProductID (revive)
+func getToolVersionsProductId(pluginName string) string {
+	if productId, found := toolVersionsPluginNames[strings.ToLower(pluginName)]; found {
+		return productId
+	}
+	if product := lib.GetProductById(pluginName); product != nil {
+		return product.GetId()
+	}
+	return ""
+}
+
+// getVersionFromToolVersions : read version of the product from `.tool-versions` file.
+// Returns empty string if the file has no usable version of the product.
+// See https://asdf-vm.com/manage/configuration.html#tool-versions
+func getVersionFromToolVersions(filePath string, productId string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(getToolVersionsProductId(fields[0]), productId) {
+			continue
+		}
+		// Several versions may be listed as fallbacks: the first one usable by tfswitch wins
+		for _, version := range fields[1:] {
+			if version == "system" || strings.HasPrefix(version, "ref:") || strings.HasPrefix(version, "path:") {
+				logger.Debugf("Skipping unsupported %q version of %q in %q", version, fields[0], filePath)
+				continue
+			}
+			return version, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func GetParamsFromToolVersions(params Params) (Params, error) {
+	filePath := findVersionFile(params, toolVersionsFileName)
+	if filePath != "" {
+		logger.Infof("Reading configuration from %q", filePath)
+		version, err := getVersionFromToolVersions(filePath, getProductIdOrDefault(params))
+		if err != nil {
+			logger.Errorf("Could not read file content from %q: %v", filePath, err)
+			return params, err
+		}
+		params.Version = version
+		params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: filePath, Version: params.Version})
+		logger.Debugf("Using version from %q: %q", filePath, params.Version)
+	}
+	return params, nil
+}
+
+func toolVersionsFileExists(params Params) bool {
+	return findVersionFile(params, toolVersionsFileName) != ""
+}
@@ -0,0 +1,57 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestGetParamsFromToolVersions(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	fileContent := "# Pinned tools\nnodejs 20.11.0\nterraform 1.6.6 # Comment\n\ntofu ref:main 1.7.2\nterragrunt system\n"
+	for product, expected := range map[string]string{
+		"":           "1.6.6",
+		"terraform":  "1.6.6",
+		"opentofu":   "1.7.2",
+		"terragrunt": "",
+		"packer":     "",
+	} {
+		t.Run(product, func(t *testing.T) {
+			_, moduleDir := createTestRepo(t)
+			writeTestFile(t, moduleDir, toolVersionsFileName, fileContent)
+
+			params, err := GetParamsFromToolVersions(Params{ChDirPath: moduleDir, Product: product})
+			if err != nil {
+				t.Fatalf("Expected no error. Got: %v", err)
+			}
+			if params.Version != expected {
+				t.Errorf("Version from %s not read correctly. Expected: %q, Actual: %q", toolVersionsFileName, expected, params.Version)
+			}
+		})
+	}
+}
+
+func TestGetParamsFromToolVersions_precedence(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	repoDir, moduleDir := createTestRepo(t)
+	liveDir := filepath.Dir(moduleDir)
+	writeTestFile(t, repoDir, tfSwitchFileName, "1.5.0")
+	writeTestFile(t, moduleDir, toolVersionsFileName, "nodejs 20.11.0\n")
+
+	// File without the product doesn't hide version files in parent directories
+	params := Params{ChDirPath: moduleDir, Product: "terraform"}
+	if toolVersionsFileExists(params) || !tfSwitchFileExists(params) {
+		t.Errorf("Expected %q in parent directory to be used", tfSwitchFileName)
+	}
+
+	// Other version files in the same directory take precedence
+	writeTestFile(t, liveDir, toolVersionsFileName, "terraform 1.6.6\n")
+	writeTestFile(t, liveDir, tfSwitchFileName, "1.7.0")
+	params = Params{ChDirPath: liveDir, Product: "terraform"}
+	params = getVersionParams(params)
+	if expected := "1.7.0"; params.Version != expected {
+		t.Errorf("Expected version from %s %q, got %q", tfSwitchFileName, expected, params.Version)
+	}
+}
@@ -0,0 +1,103 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	semver "github.com/hashicorp/go-version"
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+// VersionConflictExitCode : exit code when a pinned version does not satisfy a version constraint in strict mode
+const VersionConflictExitCode = 3
+
+// VersionSource : version pinned, or version constraint declared, by one of the sources of the configuration
+type VersionSource struct {
+	Source     string // Path of the file, environment variable or command line
+	Version    string // Pinned version (resolved, if pinned by a keyword or constraint), empty for constraints
+	Constraint string // Version constraint, empty for pinned versions
+}
+
+// addVersionSource : record version pinned, or constraint declared, by the source
+func addVersionSource(sources []VersionSource, source VersionSource) []VersionSource {
+	// Clip so that copies of the parameters (e.g. per product) don't share the appended sources
+	return append(slices.Clip(sources), source)
+}
+
+// joinVersionConstraints : combined version constraint which satisfies all the constraints
+func joinVersionConstraints(sources []VersionSource) string {
+	var constraints []string
+	for _, source := range sources {
+		constraints = append(constraints, source.Constraint)
+	}
+	return strings.Join(lib.RemoveDuplicateStrings(constraints), ", ")
+}
+
+// findVersionConflicts : check every pinned version against every version constraint,
+// return a description of each mismatch naming both sources
+func findVersionConflicts(sources []VersionSource) []string {
+	var conflicts []string
+	for _, pin := range sources {
+		if pin.Version == "" {
+			continue
+		}
+		version, err := semver.NewVersion(pin.Version)
+		if err != nil {
+			logger.Debugf("Not checking version %q from %q against constraints: %v", pin.Version, pin.Source, err)
+			continue
+		}
+		for _, requirement := range sources {
+			if requirement.Constraint == "" {
+				continue
+			}
+			constraint, err := semver.NewConstraint(requirement.Constraint)
+			if err != nil {
+				logger.Debugf("Not checking versions against constraint %q from %q: %v", requirement.Constraint, requirement.Source, err)
+				continue
+			}
+			if !constraint.Check(version) {
+				conflicts = append(conflicts, fmt.Sprintf("Version %q from %q does not satisfy constraint %q from %q", pin.Version, pin.Source, requirement.Constraint, requirement.Source))
+			}
+		}
+	}
+	return conflicts
+}
+
+// IsVersionResolvingRun : whether the run installs (or shows) the version resolved from the configuration,
+// as opposed to e.g. listing versions, installing the latest version or explaining the parameters
+func IsVersionResolvingRun(params Params) bool {
+	switch {
+	case params.VersionFlag, params.HelpFlag, len(params.ConfigCommand) > 0, params.ExplainFormat != "", params.MatchVersionRequirement != "":
+		return false
+	case params.ListAllFlag, params.LatestFlag, params.ShowLatestFlag:
+		return false
+	case params.LatestPre != "", params.ShowLatestPre != "", params.LatestStable != "", params.ShowLatestStable != "":
+		return false
+	}
+	return true
+}
+
+// CheckVersionConflicts : report pinned versions which don't satisfy version constraints of the configuration
+// (of each product, when several are switched at once). Return false on conflicts.
+// Conflicts are warnings, unless in strict mode (see `--strict`).
+func CheckVersionConflicts(params Params) bool {
+	productsParams := params.ProductsParams
+	if len(productsParams) == 0 {
+		productsParams = []Params{params}
+	}
+
+	var conflicts []string
+	for _, productParams := range productsParams {
+		conflicts = append(conflicts, findVersionConflicts(productParams.VersionSources)...)
+	}
+	for _, conflict := range conflicts {
+		if params.StrictVersion {
+			logger.Error(conflict)
+		} else {
+			logger.Warn(conflict)
+		}
+	}
+	return len(conflicts) == 0
+}
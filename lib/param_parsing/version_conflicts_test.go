@@ -0,0 +1,93 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestFindVersionConflicts(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	sources := []VersionSource{
+		{Source: ".terraform-version", Version: "1.4.0"},
+		{Source: "TF_VERSION", Version: "1.5.7"},
+		{Source: ".tfswitchrc", Version: "latest"}, // Not a version, not checked
+		{Source: "versions.tf", Constraint: ">= 1.5"},
+		{Source: "modules/vpc/versions.tf", Constraint: "< 1.6, >= 1.2"},
+	}
+	expected := []string{
+		`Version "1.4.0" from ".terraform-version" does not satisfy constraint ">= 1.5" from "versions.tf"`,
+	}
+	if conflicts := findVersionConflicts(sources); !slices.Equal(conflicts, expected) {
+		t.Errorf("Version conflicts not found as expected. Expected: %q, Actual: %q", expected, conflicts)
+	}
+
+	sources = append(sources, VersionSource{Source: sourceCommandLineArg, Version: "1.6.0"})
+	if conflicts := findVersionConflicts(sources); len(conflicts) != 2 {
+		t.Errorf("Expected 2 version conflicts, got: %q", conflicts)
+	}
+
+	if !CheckVersionConflicts(Params{VersionSources: sources[1:3]}) {
+		t.Error("Expected no version conflicts without constraints")
+	}
+	if CheckVersionConflicts(Params{StrictVersion: true, VersionSources: sources}) {
+		t.Error("Expected version conflicts to be reported")
+	}
+}
+
+func TestIsVersionResolvingRun(t *testing.T) {
+	if !IsVersionResolvingRun(Params{Version: "1.5.7"}) || !IsVersionResolvingRun(Params{ShowRequiredFlag: true}) {
+		t.Error("Expected switching to the resolved version to check version conflicts")
+	}
+	for name, params := range map[string]Params{
+		"list-all":      {ListAllFlag: true},
+		"latest":        {LatestFlag: true},
+		"latest-stable": {LatestStable: "1.5"},
+		"show-latest":   {ShowLatestFlag: true},
+		"explain":       {ExplainFormat: ExplainFormatTable},
+		"config":        {ConfigCommand: []string{configCommandName, configValidateCommand}},
+		"match":         {MatchVersionRequirement: "1.5.7"},
+	} {
+		if IsVersionResolvingRun(params) {
+			t.Errorf("Expected %q run not to check version conflicts", name)
+		}
+	}
+}
+
+func TestGetVersionParams_version_sources(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"versions":{"1.4.0":{},"1.5.7":{},"1.6.2":{}}}`))
+	}))
+	defer server.Close()
+
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, ".terraform-version", "1.4.0\n")
+	writeTestFile(t, moduleDir, "versions.tf", `terraform { required_version = ">= 1.5" }`)
+	params := Params{ChDirPath: moduleDir, Product: "terraform", MirrorURL: server.URL + "/terraform/index.json", SearchBoundary: SearchBoundaryGit}
+	params.ProductEntity = lib.GetProductById(params.Product)
+
+	params = getVersionParams(params)
+	expected := []VersionSource{
+		{Source: ".terraform-version", Version: "1.4.0"},
+		{Source: "versions.tf", Constraint: ">= 1.5"},
+	}
+	var sources []VersionSource
+	for _, source := range params.VersionSources {
+		sources = append(sources, VersionSource{Source: filepath.Base(source.Source), Version: source.Version, Constraint: source.Constraint})
+	}
+	if !slices.Equal(sources, expected) {
+		t.Errorf("Version sources not recorded as expected. Expected: %+v, Actual: %+v", expected, sources)
+	}
+	if expected := "1.6.2"; params.Version != expected {
+		t.Errorf("Version not resolved as expected. Expected: %q, Actual: %q", expected, params.Version)
+	}
+	if CheckVersionConflicts(params) {
+		t.Error("Expected conflict of .terraform-version with required_version")
+	}
+}
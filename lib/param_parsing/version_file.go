@@ -0,0 +1,76 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+// getProductIdOrDefault : ID of the product to read version files for, defaulting to the default product
+//
+//nolint:revive // FIXME: var-naming: func getProductIdOrDefault should be getProductIDOrDefault (revive)
+func getProductIdOrDefault(params Params) string {
+	if params.Product == "" {
+		return lib.DefaultProductId
+	}
+	return params.Product
+}
+
+// getProductOrDefault : product to read the configuration for, defaulting to the default product (see getProductIdOrDefault).
+// Nil if the product is unknown.
+func getProductOrDefault(params Params) lib.Product {
+	if params.ProductEntity != nil {
+		return params.ProductEntity
+	}
+	return lib.GetProductById(getProductIdOrDefault(params))
+}
+
+// getProductVersionFileNames : version files of the product (e.g. `.terraform-version` for Terraform,
+// `.opentofu-version` for OpenTofu), in the order of increasing precedence
+func getProductVersionFileNames(params Params) []string {
+	if product := getProductOrDefault(params); product != nil {
+		return product.GetVersionFileNames()
+	}
+	return nil
+}
+
+// GetParamsFromVersionFile : read version from the version file of the product
+func GetParamsFromVersionFile(params Params) (Params, error) {
+	for _, fileName := range getProductVersionFileNames(params) {
+		filePath := findVersionFile(params, fileName)
+		if filePath == "" {
+			continue
+		}
+		logger.Infof("Reading configuration from %q", filePath)
+		version, err := readVersionFile(filePath)
+		if err != nil {
+			logger.Errorf("Could not read file content at %q: %v", filePath, err)
+			return params, err
+		}
+		if params.Version, err = resolveVersion(params, version, filePath); err != nil {
+			return params, err
+		}
+		params.VersionSources = addVersionSource(params.VersionSources, VersionSource{Source: filePath, Version: params.Version})
+		logger.Debugf("Using version from %q: %q", filePath, params.Version)
+	}
+	return params, nil
+}
+
+// findProductVersionFile : path of the product version file taking precedence, empty if none found
+func findProductVersionFile(params Params) string {
+	var filePath string
+	for _, fileName := range getProductVersionFileNames(params) {
+		if found := findVersionFile(params, fileName); found != "" {
+			filePath = found
+		}
+	}
+	return filePath
+}
+
+func versionFileExists(params Params) bool {
+	for _, fileName := range getProductVersionFileNames(params) {
+		if findVersionFile(params, fileName) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestGetParamsFromVersionFile(t *testing.T) {
+	var params Params
+	params.ChDirPath = "../../test-data/integration-tests/test_terraform-version"
+	params, err := GetParamsFromVersionFile(params)
+	expected := "0.11.0"
+	if err != nil {
+		t.Fatalf("Got error '%s'", err)
+	}
+	if params.Version != expected {
+		t.Errorf("Version from .terraform-version not read correctly. Got: %v, Expect: %v", params.Version, expected)
+	}
+}
+
+func TestGetParamsFromVersionFile_no_version(t *testing.T) {
+	var params Params
+	params.ChDirPath = "../../test-data/skip-integration-tests/test_versiontf_no_version_constraint"
+	params, err := GetParamsFromVersionFile(params)
+	if err != nil {
+		t.Fatalf("Got error '%s'", err)
+	}
+	if params.Version != "" {
+		t.Errorf("Expected empty version string. Got: %v", params.Version)
+	}
+}
+
+func TestGetParamsFromVersionFile_no_file(t *testing.T) {
+	var params Params
+	params.ChDirPath = "../../test-data/skip-integration-tests/test_no_file"
+	params, err := GetParamsFromVersionFile(params)
+	if err != nil {
+		t.Fatalf("Got error '%s'", err)
+	}
+	if params.Version != "" {
+		t.Errorf("Expected empty version string. Got: %v", params.Version)
+	}
+}
+
+func TestGetParamsFromVersionFile_product(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, ".terraform-version", "1.6.6")
+	writeTestFile(t, moduleDir, ".terragrunt-version", "0.58.0")
+
+	for product, expected := range map[string]string{
+		"terraform":  "1.6.6",
+		"terragrunt": "0.58.0",
+		"opentofu":   "", // Not forced to Terraform version
+	} {
+		params, err := GetParamsFromVersionFile(Params{ChDirPath: moduleDir, Product: product})
+		if err != nil {
+			t.Fatalf("Got error '%s'", err)
+		}
+		if params.Version != expected {
+			t.Errorf("Version of %s not read correctly. Got: %q, Expect: %q", product, params.Version, expected)
+		}
+	}
+
+	writeTestFile(t, moduleDir, ".opentofu-version", "1.7.2")
+	params, _ := GetParamsFromVersionFile(Params{ChDirPath: moduleDir, Product: "opentofu"})
+	if expected := "1.7.2"; params.Version != expected {
+		t.Errorf("Version of OpenTofu not read correctly. Got: %q, Expect: %q", params.Version, expected)
+	}
+}
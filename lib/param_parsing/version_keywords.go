@@ -0,0 +1,110 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+// Keywords accepted in version files in place of a version, compatible with tfenv:
+// https://github.com/tfutils/tfenv#terraform-version-file
+const (
+	versionKeywordLatest        = "latest"         // Latest stable version
+	versionKeywordLatestRegex   = "latest:"        // Latest version matching the regular expression following the colon
+	versionKeywordLatestAllowed = "latest-allowed" // Latest version allowed by `required_version` of the module
+	versionKeywordMinRequired   = "min-required"   // Minimum version allowed by `required_version` of the module
+)
+
+// readVersionFile : read the first line of the version file which is neither blank nor a comment
+func readVersionFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			return line, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// getVersionsMirror : product to resolve versions of and the mirror listing its versions
+func getVersionsMirror(params Params) (lib.Product, string) {
+	product := getProductOrDefault(params)
+	mirrorURL := params.MirrorURL
+	if mirrorURL == "" && product != nil {
+		mirrorURL = product.GetDefaultMirrorUrl()
+	}
+	return product, mirrorURL
+}
+
+// resolveVersion : resolve version read from the version file (or the environment) to the version of the product:
+// version constraints (e.g. `~> 1.6`) resolve to the latest version matching them, keywords as per resolveVersionKeyword.
+// Constraints are kept as is when matching the version requirement, as they are the requirement to match.
+func resolveVersion(params Params, version string, source string) (string, error) {
+	if !lib.IsVersionConstraint(version) {
+		return resolveVersionKeyword(params, version, source)
+	}
+	if params.MatchVersionRequirement != "" {
+		return version, nil
+	}
+
+	product, mirrorURL := getVersionsMirror(params)
+	logger.Infof("Resolving %q version constraint from %q", version, source)
+	resolvedVersion, err := lib.GetSemver(product, version, mirrorURL)
+	if err != nil {
+		return "", fmt.Errorf("no version found matching %q constraint from %q: %v", version, source, err)
+	}
+	return resolvedVersion, nil
+}
+
+// resolveVersionKeyword : resolve tfenv keyword read from the version file to the version of the product.
+// Versions which are not keywords are returned as is.
+func resolveVersionKeyword(params Params, version string, filePath string) (string, error) {
+	if version != versionKeywordLatest && version != versionKeywordLatestAllowed &&
+		version != versionKeywordMinRequired && !strings.HasPrefix(version, versionKeywordLatestRegex) {
+		return version, nil
+	}
+
+	product, mirrorURL := getVersionsMirror(params)
+	logger.Infof("Resolving %q keyword from %q", version, filePath)
+
+	switch version {
+	case versionKeywordLatest:
+		return lib.GetLatestVersion(product, mirrorURL)
+	case versionKeywordLatestAllowed, versionKeywordMinRequired:
+		constraintParams := params
+		constraintParams.Product = product.GetId()
+		constraintParams, err := getConstraintFromVersionsTF(constraintParams)
+		if err != nil {
+			return "", err
+		}
+		if constraintParams.VersionRequirement == "" {
+			if version == versionKeywordMinRequired {
+				return "", fmt.Errorf("%q keyword in %q requires %q constraint in %s", version, filePath, requiredVersionAttrName, paramTypeVersionTF)
+			}
+			logger.Warnf("No %q constraint found in %s, using latest version for %q keyword", requiredVersionAttrName, paramTypeVersionTF, version)
+			return lib.GetLatestVersion(product, mirrorURL)
+		}
+		if version == versionKeywordMinRequired {
+			return lib.GetMinSemver(product, constraintParams.VersionRequirement, mirrorURL)
+		}
+		return lib.GetSemver(product, constraintParams.VersionRequirement, mirrorURL)
+	default:
+		pattern, err := regexp.Compile(strings.TrimPrefix(version, versionKeywordLatestRegex))
+		if err != nil {
+			return "", fmt.Errorf("invalid regular expression in %q keyword in %q: %v", version, filePath, err)
+		}
+		return lib.GetLatestVersionMatching(product, mirrorURL, pattern)
+	}
+}
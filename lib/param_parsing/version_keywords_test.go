@@ -0,0 +1,139 @@
+//nolint:revive // FIXME: don't use an underscore in package name
+package param_parsing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/warrensbox/terraform-switcher/lib"
+)
+
+func TestReadVersionFile(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	tempDir := t.TempDir()
+	writeTestFile(t, tempDir, tfSwitchFileName, "# Pinned by platform team\n\n  latest:^1\\.5 # Comment\n1.6.0\n")
+
+	version, err := readVersionFile(filepath.Join(tempDir, tfSwitchFileName))
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := `latest:^1\.5`; version != expected {
+		t.Errorf("Version not read correctly. Expected: %q, Actual: %q", expected, version)
+	}
+}
+
+func TestResolveVersionKeyword(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"versions":{"1.4.7":{},"1.5.0":{},"1.5.7":{},"1.6.0":{},"1.7.0-beta1":{}}}`))
+	}))
+	defer server.Close()
+
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, "versions.tf", `terraform { required_version = ">= 1.5.0, < 1.6.0" }`)
+	params := Params{ChDirPath: moduleDir, Product: "terraform", MirrorURL: server.URL + "/terraform/index.json"}
+
+	for keyword, expected := range map[string]string{
+		"1.4.7":            "1.4.7",
+		"latest":           "1.6.0",
+		`latest:^1\.5`:     "1.5.7",
+		"latest:beta":      "1.7.0-beta1",
+		"latest-allowed":   "1.5.7",
+		"min-required":     "1.5.0",
+		"latest:^2":        "",
+		"latest:[invalid(": "",
+	} {
+		version, err := resolveVersionKeyword(params, keyword, tfSwitchFileName)
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Expected error for %q keyword, got version %q", keyword, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error for %q keyword. Got: %v", keyword, err)
+		}
+		if version != expected {
+			t.Errorf("Version of %q keyword not resolved correctly. Expected: %q, Actual: %q", keyword, expected, version)
+		}
+	}
+
+	// Keywords deriving version from the module require its constraint
+	params.ChDirPath = t.TempDir()
+	params.SearchBoundary = SearchBoundaryNone
+	if version, err := resolveVersionKeyword(params, "latest-allowed", tfSwitchFileName); err != nil || version != "1.6.0" {
+		t.Errorf("Expected latest version without module constraint, got %q (error: %v)", version, err)
+	}
+	if _, err := resolveVersionKeyword(params, "min-required", tfSwitchFileName); err == nil {
+		t.Error("Expected error for min-required keyword without module constraint. Got nil")
+	}
+}
+
+func TestResolveVersion_constraint(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"versions":{"1.4.7":{},"1.5.0":{},"1.5.7":{},"1.6.0":{},"1.6.2":{},"1.7.0-beta1":{}}}`))
+	}))
+	defer server.Close()
+	params := Params{ChDirPath: t.TempDir(), Product: "terraform", MirrorURL: server.URL + "/terraform/index.json"}
+
+	for constraint, expected := range map[string]string{
+		"1.4.7":          "1.4.7",
+		"1.7.0-beta1":    "1.7.0-beta1",
+		"~> 1.5.0":       "1.5.7",
+		"~> 1.6":         "1.6.2",
+		">= 1.5, < 1.6":  "1.5.7",
+		"latest:^1\\.5":  "1.5.7",
+		"~> 2.0":         "",
+		">= 1.5, banana": ">= 1.5, banana", // Neither constraint nor keyword, rejected on install
+	} {
+		version, err := resolveVersion(params, constraint, tfSwitchFileName)
+		if expected == "" {
+			if err == nil {
+				t.Errorf("Expected error for %q constraint, got version %q", constraint, version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected no error for %q constraint. Got: %v", constraint, err)
+		}
+		if version != expected {
+			t.Errorf("Version of %q constraint not resolved correctly. Expected: %q, Actual: %q", constraint, expected, version)
+		}
+	}
+
+	// Constraint is the requirement to match against
+	params.MatchVersionRequirement = "1.6.1"
+	if version, err := resolveVersion(params, "~> 1.6", tfSwitchFileName); err != nil || version != "~> 1.6" {
+		t.Errorf("Expected constraint kept as is when matching version requirement, got %q (error: %v)", version, err)
+	}
+}
+
+func TestGetVersionParams_constraint(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"versions":{"1.5.0":{},"1.5.7":{},"1.6.0":{},"1.6.2":{},"1.7.1":{}}}`))
+	}))
+	defer server.Close()
+
+	_, moduleDir := createTestRepo(t)
+	params := Params{ChDirPath: moduleDir, Product: "terraform", MirrorURL: server.URL + "/terraform/index.json", SearchBoundary: SearchBoundaryGit}
+	params.ProductEntity = lib.GetProductById(params.Product)
+
+	writeTestFile(t, moduleDir, tfSwitchFileName, "~> 1.5.0\n")
+	if version := getVersionParams(params).Version; version != "1.5.7" {
+		t.Errorf("Version from %s constraint not resolved correctly. Expected: %q, Actual: %q", tfSwitchFileName, "1.5.7", version)
+	}
+
+	writeTestFile(t, moduleDir, ".terraform-version", ">= 1.5, < 1.7\n")
+	if version := getVersionParams(params).Version; version != "1.6.2" {
+		t.Errorf("Version from .terraform-version constraint not resolved correctly. Expected: %q, Actual: %q", "1.6.2", version)
+	}
+
+	t.Setenv("TF_VERSION", "~> 1.7")
+	if version := getVersionParams(params).Version; version != "1.7.1" {
+		t.Errorf("Version from TF_VERSION constraint not resolved correctly. Expected: %q, Actual: %q", "1.7.1", version)
+	}
+}
@@ -7,7 +7,9 @@ package param_parsing
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	semver "github.com/hashicorp/go-version"
@@ -83,66 +85,155 @@ func getVersionConstraintsFromHCLFile(fileName string, hclFile *hcl.File) ([]str
 	return constraints, nil
 }
 
-func getVersionConstraintsFromFiles(filesPath []string) (string, error) {
+// getVersionConstraintsFromFiles : version constraints of the files of a module, merged like the
+// configuration loader does: constraints of the primary files add up, while an override file
+// (`override.tf`, `*_override.tf`) declaring a constraint replaces all the ones before it
+func getVersionConstraintsFromFiles(filesPath []string) ([]VersionSource, error) {
 	parser := hclparse.NewParser()
+	var primaryFiles, overrideFiles []*hcl.File
+	var primaryNames, overrideNames []string
 	for _, filePath := range filesPath {
-		_, diagnostics := parser.ParseHCLFile(filePath)
+		hclFile, diagnostics := parseConfigFile(parser, filePath)
 		if diagnostics.HasErrors() {
-			return "", fmt.Errorf("Could not parse HCL file %q: %v", filePath, diagnostics.Error())
+			return nil, fmt.Errorf("Could not parse HCL file %q: %v", filePath, diagnostics.Error())
+		}
+		if isOverrideFile(filePath) {
+			overrideFiles = append(overrideFiles, hclFile)
+			overrideNames = append(overrideNames, filePath)
+		} else {
+			primaryFiles = append(primaryFiles, hclFile)
+			primaryNames = append(primaryNames, filePath)
 		}
 	}
 
-	var constraints []string
-	for fileName, hclFile := range parser.Files() {
-		parsedConstraints, err := getVersionConstraintsFromHCLFile(fileName, hclFile)
+	var constraints []VersionSource
+	for i, hclFile := range primaryFiles {
+		parsedConstraints, err := getVersionConstraintsFromHCLFile(primaryNames[i], hclFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(parsedConstraints) > 0 {
+			constraints = append(constraints, VersionSource{Source: primaryNames[i], Constraint: strings.Join(parsedConstraints, ", ")})
+		}
+	}
+	for i, hclFile := range overrideFiles {
+		parsedConstraints, err := getVersionConstraintsFromHCLFile(overrideNames[i], hclFile)
 		if err != nil {
-			return "", err
+			return nil, err
+		}
+		if len(parsedConstraints) > 0 {
+			logger.Debugf("Version constraint overridden by %q", overrideNames[i])
+			constraints = []VersionSource{{Source: overrideNames[i], Constraint: strings.Join(parsedConstraints, ", ")}}
 		}
-		constraints = append(constraints, parsedConstraints...)
 	}
 
-	return strings.Join(constraints, ", "), nil
+	return constraints, nil
+}
+
+// isOverrideFile : whether the configuration file is an override file, e.g. `override.tf` or `versions_override.tf.json`
+func isOverrideFile(filePath string) bool {
+	baseName := strings.TrimSuffix(filepath.Base(filePath), ".json")
+	stem := strings.TrimSuffix(baseName, filepath.Ext(baseName))
+	return stem == "override" || strings.HasSuffix(stem, "_override")
 }
 
 func getConstraintFromVersionsTF(params Params) (Params, error) {
-	relPath, err := lib.GetRelativePath(params.ChDirPath)
-	if err != nil {
-		return params, err
-	}
+	searchDirs := getSearchDirs(params)
+	logger.Infof("Reading version constraint from %s at %q", paramTypeVersionTF, searchDirs[0])
 
-	logger.Infof("Reading version constraint from %s at %q", paramTypeVersionTF, relPath)
+	// The nearest module wins, even if it doesn't declare version constraint:
+	// constraints of parent directories belong to other modules
+	for _, relPath := range searchDirs {
+		constraints, err := getConstraintFromModuleTree(params, relPath)
+		if err != nil {
+			return params, err
+		}
+		if len(constraints) > 0 {
+			params.VersionRequirement = joinVersionConstraints(constraints)
+			for _, constraint := range constraints {
+				params.VersionSources = addVersionSource(params.VersionSources, constraint)
+			}
+			logger.Debugf("Using version constraint from %s at %q: %q", paramTypeVersionTF, relPath, params.VersionRequirement)
+			return params, nil
+		}
+		if hclFiles, _, err := getModuleFiles(params, relPath); err == nil && len(hclFiles) > 0 {
+			logger.Debugf("No version constraint declared by %s at %q, not searching parent directories", paramTypeVersionTF, relPath)
+			return params, nil
+		}
+	}
+	return params, nil
+}
 
-	extensionsPerProduct := lib.GetProductById(params.Product).GetFileExtensions()
-	var hclFiles []string
+// getModuleFiles : configuration files of the module at relPath, in native (e.g. `*.tf`)
+// and JSON (e.g. `*.tf.json`) syntax, along with the globs used to find them.
+// A file is ignored if a file with the same name and an extension of higher precedence exists
+// (e.g. `main.tf` next to `main.tofu` for OpenTofu). Files are sorted by name, as read by the loader.
+func getModuleFiles(params Params, relPath string) ([]string, []string, error) {
+	filesByName := map[string]string{}
 	var fileGlobs []string
-	for _, ext := range extensionsPerProduct {
-		globPattern := fmt.Sprintf("*.%s", ext)
-		fileGlobs = append(fileGlobs, globPattern)
-		files, globErr := filepath.Glob(filepath.Join(relPath, globPattern))
-		if globErr != nil {
-			return params, fmt.Errorf("Could not list %s files in %q: %v", globPattern, relPath, globErr)
+	product := getProductOrDefault(params)
+	if product == nil {
+		return nil, nil, fmt.Errorf("Unknown product %q", params.Product)
+	}
+	for _, ext := range product.GetFileExtensions() {
+		for _, suffix := range []string{"." + ext, "." + ext + ".json"} {
+			globPattern := "*" + suffix
+			fileGlobs = append(fileGlobs, globPattern)
+			matches, globErr := filepath.Glob(filepath.Join(relPath, globPattern))
+			if globErr != nil {
+				return nil, nil, fmt.Errorf("Could not list %s files in %q: %v", globPattern, relPath, globErr)
+			}
+			for _, filePath := range matches {
+				// Native and JSON syntax files are distinct, e.g. `main.tofu` does not shadow `main.tf.json`
+				name := strings.TrimSuffix(filePath, suffix)
+				if strings.HasSuffix(suffix, ".json") {
+					name += ".json"
+				}
+				if shadowed, found := filesByName[name]; found {
+					logger.Debugf("Ignoring %q as %q takes precedence", shadowed, filePath)
+				}
+				filesByName[name] = filePath
+			}
 		}
-		hclFiles = append(hclFiles, files...)
 	}
 
+	files := slices.Collect(maps.Values(filesByName))
+	slices.SortFunc(files, func(a, b string) int {
+		return strings.Compare(filepath.Base(a), filepath.Base(b))
+	})
+	return files, fileGlobs, nil
+}
+
+// parseConfigFile : parse configuration file in native or JSON syntax, depending on its extension
+func parseConfigFile(parser *hclparse.Parser, filePath string) (*hcl.File, hcl.Diagnostics) {
+	if strings.HasSuffix(filePath, ".json") {
+		return parser.ParseJSONFile(filePath)
+	}
+	return parser.ParseHCLFile(filePath)
+}
+
+// getConstraintFromModuleDir : read version constraints from files of the module at relPath
+func getConstraintFromModuleDir(params Params, relPath string) ([]VersionSource, error) {
+	logger.Debugf("Reading version constraint from %s at %q", paramTypeVersionTF, relPath)
+
+	hclFiles, fileGlobs, err := getModuleFiles(params, relPath)
+	if err != nil {
+		return nil, err
+	}
 	if len(hclFiles) == 0 {
 		logger.Debugf("No %s files found in %q", strings.Join(fileGlobs, ", "), relPath)
-		return params, nil
+		return nil, nil
 	}
 
-	versionRequirements, err := getVersionConstraintsFromFiles(hclFiles)
+	constraints, err := getVersionConstraintsFromFiles(hclFiles)
 	if err != nil {
-		return params, err
+		return nil, err
 	}
 
-	if versionRequirements == "" {
+	if len(constraints) == 0 {
 		logger.Debugf("No version requirements found in %s files in %q", strings.Join(fileGlobs, ", "), relPath)
-		return params, nil
 	}
-
-	params.VersionRequirement = versionRequirements
-	logger.Debugf("Using version constraint from %s at %q: %q", paramTypeVersionTF, relPath, params.VersionRequirement)
-	return params, nil
+	return constraints, nil
 }
 
 func GetVersionFromVersionsTF(params Params) (Params, error) {
@@ -157,7 +248,8 @@ func GetVersionFromVersionsTF(params Params) (Params, error) {
 	}
 
 	if params.MatchVersionRequirement == "" {
-		version, err2 := lib.GetSemver(params.ProductEntity, params.VersionRequirement, params.MirrorURL)
+		product, mirrorURL := getVersionsMirror(params)
+		version, err2 := lib.GetSemver(product, params.VersionRequirement, mirrorURL)
 		if err2 != nil {
 			logger.Errorf("No version found matching %q", params.VersionRequirement)
 			return params, err2
@@ -119,3 +119,57 @@ func TestGetVersionFromVersionsTF_non_existent_constraint(t *testing.T) {
 		}
 	}
 }
+
+func TestGetConstraintFromVersionsTF_json_syntax(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, "main.tf.json", `{"terraform": {"required_version": ">= 1.5.0"}}`)
+	writeTestFile(t, moduleDir, "cdk.tf.json", `{"terraform": [{"required_version": "< 1.7.0"}], "module": {"vpc": {"source": "terraform-aws-modules/vpc/aws"}}}`)
+	writeTestFile(t, moduleDir, "main.tofu.json", `{"terraform": {"required_version": "~> 1.8.0"}}`)
+
+	params, err := getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: "terraform"})
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "< 1.7.0, >= 1.5.0"; params.VersionRequirement != expected {
+		t.Errorf("Unexpected version constraint. Expected: %q, Actual: %q", expected, params.VersionRequirement)
+	}
+
+	writeTestFile(t, moduleDir, "broken.tf.json", `{"terraform": {`)
+	if _, err := getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: "terraform"}); err == nil {
+		t.Error("Expected error for invalid JSON file. Got nil")
+	}
+}
+
+func TestGetConstraintFromVersionsTF_loader_semantics(t *testing.T) {
+	logger = lib.InitLogger("DEBUG")
+	_, moduleDir := createTestRepo(t)
+	writeTestFile(t, moduleDir, "versions.tf", `terraform { required_version = ">= 1.0.0" }`)
+	writeTestFile(t, moduleDir, "versions.tofu", `terraform { required_version = ">= 1.6.0" }`)
+	writeTestFile(t, moduleDir, "main.tf", `terraform { required_version = "< 2.0.0" }`)
+
+	for product, expected := range map[string]string{
+		"terraform": "< 2.0.0, >= 1.0.0",
+		"opentofu":  "< 2.0.0, >= 1.6.0", // versions.tf is ignored next to versions.tofu
+	} {
+		params, err := getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: product})
+		if err != nil {
+			t.Fatalf("Expected no error for %q. Got: %v", product, err)
+		}
+		if params.VersionRequirement != expected {
+			t.Errorf("Unexpected version constraint for %q. Expected: %q, Actual: %q", product, expected, params.VersionRequirement)
+		}
+	}
+
+	// Override files replace constraints instead of adding to them, the last one winning
+	writeTestFile(t, moduleDir, "a_override.tf", `terraform { required_version = "~> 1.5.0" }`)
+	writeTestFile(t, moduleDir, "override.tf.json", `{"terraform": {"required_version": "~> 1.7.0"}}`)
+	writeTestFile(t, moduleDir, "z_override.tf", `terraform { backend "local" {} }`)
+	params, err := getConstraintFromVersionsTF(Params{ChDirPath: moduleDir, Product: "terraform"})
+	if err != nil {
+		t.Fatalf("Expected no error. Got: %v", err)
+	}
+	if expected := "~> 1.7.0"; params.VersionRequirement != expected {
+		t.Errorf("Unexpected overridden version constraint. Expected: %q, Actual: %q", expected, params.VersionRequirement)
+	}
+}
@@ -0,0 +1,86 @@
+//nolint:staticcheck //ST1005: error strings should not be capitalized (staticcheck)
+package lib
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+// PlatformRule : availability of the product builds for the OS/CPU architecture
+type PlatformRule struct {
+	OS string
+	// Empty Arch applies the rule to all CPU architectures of the OS
+	Arch string
+	// First version built for the platform
+	Since string
+	// CPU architecture to install instead for versions older than Since
+	// (e.g. "amd64" to run under Rosetta 2 on Apple Silicon).
+	// Empty FallbackArch means older versions can't be installed on the platform.
+	FallbackArch string
+	// No version is built for the platform (Since is disregarded then).
+	// FallbackArch, if any, is installed for all versions.
+	Unavailable bool
+}
+
+// matches : whether the rule applies to the platform
+func (r PlatformRule) matches(goos, goarch string) bool {
+	return r.OS == goos && (r.Arch == "" || r.Arch == goarch)
+}
+
+// getPlatformRule : find the rule for the platform, preferring the one for exact CPU architecture
+func getPlatformRule(product Product, goos, goarch string) (PlatformRule, bool) {
+	var osRule *PlatformRule
+	for _, rule := range product.GetPlatformRules() {
+		if !rule.matches(goos, goarch) {
+			continue
+		}
+		if rule.Arch != "" {
+			return rule, true
+		}
+		if osRule == nil {
+			osRule = &rule
+		}
+	}
+	if osRule != nil {
+		return *osRule, true
+	}
+	return PlatformRule{}, false
+}
+
+// ResolvePlatformArch : get CPU architecture to install the product version for on the OS,
+// applying fallback (if any) when the version was not built for the requested platform
+func ResolvePlatformArch(product Product, productVersion, goos, goarch string) (string, error) {
+	rule, found := getPlatformRule(product, goos, goarch)
+	if !found || (rule.Since == "" && !rule.Unavailable) {
+		return goarch, nil
+	}
+
+	if rule.Unavailable {
+		if rule.FallbackArch == "" {
+			return "", fmt.Errorf("%s is not available for %q: no version is built for this platform", product.GetName(), goos+"_"+goarch)
+		}
+		logger.Infof("%s is not available for %q, falling back to %q", product.GetName(), goos+"_"+goarch, goos+"_"+rule.FallbackArch)
+		return rule.FallbackArch, nil
+	}
+
+	requestedVersion, err := version.NewVersion(productVersion)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing %q version: %v", productVersion, err)
+	}
+	sinceVersion, err := version.NewVersion(rule.Since)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing %q version of %s platform rule for %s: %v", rule.Since, product.GetName(), goos+"_"+goarch, err)
+	}
+	if !requestedVersion.LessThan(sinceVersion) {
+		return goarch, nil
+	}
+
+	if rule.FallbackArch == "" {
+		return "", fmt.Errorf("%s version %q is not available for %q: builds for this platform start with version %q",
+			product.GetName(), productVersion, goos+"_"+goarch, rule.Since)
+	}
+	logger.Infof("%s version %q is not available for %q (builds start with version %q), falling back to %q",
+		product.GetName(), productVersion, goos+"_"+goarch, rule.Since, goos+"_"+rule.FallbackArch)
+	return rule.FallbackArch, nil
+}
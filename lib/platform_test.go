@@ -0,0 +1,120 @@
+package lib
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ResolvePlatformArch(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	product := CustomProduct{
+		ProductDetails: ProductDetails{
+			ID:   "platform-test",
+			Name: "Platform Test",
+			PlatformRules: []PlatformRule{
+				{OS: "darwin", Arch: "arm64", Since: "1.0.2", FallbackArch: "amd64"},
+				{OS: "linux", Arch: "riscv64", Since: "1.5.0"},
+				{OS: "freebsd", Since: "1.2.0"},
+				{OS: "freebsd", Arch: "arm64", Since: "1.3.0"},
+				{OS: "openbsd", Unavailable: true},
+				{OS: "openbsd", Arch: "amd64"}, // Available for all versions
+				{OS: "windows", Arch: "arm64", Unavailable: true, FallbackArch: "amd64"},
+			},
+		},
+	}
+
+	tests := []struct {
+		version  string
+		goos     string
+		goarch   string
+		expected string
+	}{
+		{version: "1.0.2", goos: "darwin", goarch: "arm64", expected: "arm64"},
+		{version: "1.0.1", goos: "darwin", goarch: "arm64", expected: "amd64"},
+		{version: "0.11.0", goos: "darwin", goarch: "amd64", expected: "amd64"},
+		{version: "1.5.0", goos: "linux", goarch: "riscv64", expected: "riscv64"},
+		{version: "1.4.9", goos: "linux", goarch: "amd64", expected: "amd64"},
+		{version: "1.2.0", goos: "freebsd", goarch: "amd64", expected: "amd64"},
+		{version: "1.3.0", goos: "freebsd", goarch: "arm64", expected: "arm64"},
+		{version: "0.1.0", goos: "openbsd", goarch: "amd64", expected: "amd64"},
+		{version: "9.9.9", goos: "windows", goarch: "arm64", expected: "amd64"},
+	}
+	for _, test := range tests {
+		actual, err := ResolvePlatformArch(product, test.version, test.goos, test.goarch)
+		if err != nil {
+			t.Errorf("Unexpected error for %s %s_%s: %v", test.version, test.goos, test.goarch, err)
+			continue
+		}
+		if actual != test.expected {
+			t.Errorf("Arch for %s %s_%s not matching. Got %q, expected %q", test.version, test.goos, test.goarch, actual, test.expected)
+		}
+	}
+
+	unavailable := []struct {
+		version string
+		goos    string
+		goarch  string
+		since   string
+	}{
+		{version: "1.4.9", goos: "linux", goarch: "riscv64", since: "1.5.0"},
+		{version: "1.1.0", goos: "freebsd", goarch: "amd64", since: "1.2.0"},
+		{version: "1.2.0", goos: "freebsd", goarch: "arm64", since: "1.3.0"}, // Rule for exact arch wins
+	}
+	for _, test := range unavailable {
+		_, err := ResolvePlatformArch(product, test.version, test.goos, test.goarch)
+		if err == nil {
+			t.Errorf("Expected error for %s %s_%s. Got nil", test.version, test.goos, test.goarch)
+			continue
+		}
+		for _, expected := range []string{"Platform Test", test.version, test.goos + "_" + test.goarch, test.since} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected error to mention %q. Got %q", expected, err.Error())
+			}
+		}
+	}
+}
+
+func Test_ResolvePlatformArch_unavailable(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	tests := []struct {
+		productID string
+		goos      string
+		goarch    string
+	}{
+		{productID: "terraform", goos: "linux", goarch: "riscv64"},
+		{productID: "vault", goos: "linux", goarch: "riscv64"},
+		{productID: "opentofu", goos: "linux", goarch: "riscv64"},
+		{productID: "terragrunt", goos: "freebsd", goarch: "amd64"},
+	}
+	for _, test := range tests {
+		product := GetProductById(test.productID)
+		_, err := ResolvePlatformArch(product, "1.9.0", test.goos, test.goarch)
+		if err == nil {
+			t.Errorf("Expected error for %s on %s_%s. Got nil", product.GetName(), test.goos, test.goarch)
+			continue
+		}
+		for _, expected := range []string{product.GetName(), test.goos + "_" + test.goarch, "no version is built"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected error to mention %q. Got %q", expected, err.Error())
+			}
+		}
+	}
+
+	if actual, err := ResolvePlatformArch(GetProductById("terragrunt"), "0.58.0", "linux", "amd64"); err != nil || actual != "amd64" {
+		t.Errorf("Expected Terragrunt to be available on linux_amd64. Got %q, %v", actual, err)
+	}
+}
+
+func Test_ResolvePlatformArch_Terraform(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	terraform := GetProductById("terraform")
+	if actual, _ := ResolvePlatformArch(terraform, "1.0.1", "darwin", "arm64"); actual != "amd64" {
+		t.Errorf("Expected Terraform 1.0.1 to fall back to amd64 on darwin_arm64. Got %q", actual)
+	}
+
+	// Terraform-specific rule must not apply to other products
+	opentofu := GetProductById("opentofu")
+	if actual, _ := ResolvePlatformArch(opentofu, "1.0.1", "darwin", "arm64"); actual != "arm64" {
+		t.Errorf("Expected OpenTofu to have no fallback on darwin_arm64. Got %q", actual)
+	}
+}
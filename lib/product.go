@@ -2,6 +2,7 @@ package lib
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
@@ -23,8 +24,37 @@ type ProductDetails struct {
 	PublicKeyId            string
 	PublicKeyURLs          []string
 	PublicKeyLegacyLiteral string
-	FileExtensions         []string
-}
+	// Extensions of the configuration files, in the order of increasing precedence:
+	// a file shadows the same-named one with an extension listed before (e.g. "main.tofu" over "main.tf")
+	FileExtensions []string
+	// One of ArchiveFormat* constants. Defaults to ArchiveFormatZip
+	ArchiveFormat string
+	// One of VersionsSource* constants. Defaults to VersionsSourceJSON
+	VersionsSource string
+	// Prepended to the version in the release tag (and download path), e.g. "v" for GitHub releases
+	ReleaseTagPrefix string
+	// Availability of the builds per platform. Platforms without a rule are assumed to be built for all versions
+	PlatformRules []PlatformRule
+	// Product-specific files pinning the version (e.g. ".terraform-version"), in the order of increasing precedence
+	VersionFileNames []string
+	// Checksums of the releases are not signed, so only the checksums are verified.
+	// Releases of any other product must be signed by one of PublicKeyURLs
+	UnsignedReleases bool
+}
+
+// Formats of the release artifacts
+const (
+	ArchiveFormatZip    = "zip"    // zip archive containing the executable
+	ArchiveFormatTarGz  = "tar.gz" // gzip-compressed tar archive containing the executable
+	ArchiveFormatTarXz  = "tar.xz" // xz-compressed tar archive containing the executable
+	ArchiveFormatBinary = "binary" // uncompressed executable
+)
+
+// Sources of the list of versions, served by the product mirror
+const (
+	VersionsSourceJSON           = "json"            // JSON index, parsed by Product.GetVersionsFromJSON
+	VersionsSourceGitHubReleases = "github-releases" // GitHub releases API
+)
 
 type TerraformProduct struct {
 	ProductDetails
@@ -50,117 +80,187 @@ type Product interface {
 	GetPublicKeyLegacyLiteral() string
 	GetShaSignatureSuffix() string
 	GetArtifactUrl(mirrorURL string, version string) string
+	// Deprecated: This method has been deprecated and will be removed in v2.0.0
 	GetRecentVersionProduct(recentFile *RecentFile) []string
+	// Deprecated: This method has been deprecated and will be removed in v2.0.0
 	SetRecentVersionProduct(recentFile *RecentFile, versions []string)
 	GetFileExtensions() []string
 	GetVersionsFromJSON(body []byte) ([]string, error)
-}
-
-// Terraform Product
-
-// Struct representing Terraform JSON:
-// https://releases.hashicorp.com/terraform/index.json
-type TerraformVersionJSON struct {
-	Versions map[string]struct{} `json:"versions"`
+	GetArchiveFormat() string
+	GetArtifactFileName(archivePrefix, version, goos, goarch string) string
+	GetChecksumsFileName(archivePrefix, version string) string
+	GetVersionsSource() string
+	GetReleaseTagPrefix() string
+	GetPlatformRules() []PlatformRule
+	GetVersionFileNames() []string
+	HasUnsignedReleases() bool
 }
 
 // nolint:revive // FIXME: var-naming: method GetId should be GetID (revive)
-func (p TerraformProduct) GetId() string {
+func (p ProductDetails) GetId() string {
 	return p.ID
 }
 
-func (p TerraformProduct) GetName() string {
+func (p ProductDetails) GetName() string {
 	return p.Name
 }
 
 // nolint:revive // FIXME: var-naming: method GetDefaultMirrorUrl should be GetDefaultMirrorURL (revive)
-func (p TerraformProduct) GetDefaultMirrorUrl() string {
+func (p ProductDetails) GetDefaultMirrorUrl() string {
 	return p.DefaultMirror
 }
 
-func (p TerraformProduct) GetDefaultDownloadMirrorURL() string {
+func (p ProductDetails) GetDefaultDownloadMirrorURL() string {
 	return p.DefaultDownloadMirror
 }
 
-func (p TerraformProduct) GetVersionPrefix() string {
+func (p ProductDetails) GetVersionPrefix() string {
 	return p.VersionPrefix
 }
 
-func (p TerraformProduct) GetExecutableName() string {
+func (p ProductDetails) GetExecutableName() string {
 	return p.ExecutableName
 }
 
-func (p TerraformProduct) GetArchivePrefix() string {
+func (p ProductDetails) GetArchivePrefix() string {
 	return p.ArchivePrefix
 }
 
-// nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
-func (p TerraformProduct) GetArtifactUrl(mirrorURL string, version string) string {
-	// Backwards compatible with old tests
-	downloadUrl := p.DefaultMirror
+// nolint:revive // FIXME: var-naming: method GetPublicKeyId should be GetPublicKeyID (revive)
+func (p ProductDetails) GetPublicKeyId() string {
+	return p.PublicKeyId
+}
+
+func (p ProductDetails) GetPublicKeyURLs() []string {
+	return p.PublicKeyURLs
+}
+
+func (p ProductDetails) GetPublicKeyLegacyLiteral() string {
+	return p.PublicKeyLegacyLiteral
+}
+
+func (p ProductDetails) GetFileExtensions() []string {
+	return p.FileExtensions
+}
+
+func (p ProductDetails) GetPlatformRules() []PlatformRule {
+	return p.PlatformRules
+}
+
+func (p ProductDetails) GetVersionFileNames() []string {
+	return p.VersionFileNames
+}
+
+func (p ProductDetails) HasUnsignedReleases() bool {
+	return p.UnsignedReleases
+}
+
+// GetRecentVersionProduct : recent versions of the product
+//
+// Deprecated: This method has been deprecated in favor of indexing RecentFile by product ID and will be removed in v2.0.0
+func (p ProductDetails) GetRecentVersionProduct(recentFile *RecentFile) []string {
+	return (*recentFile)[p.GetId()]
+}
+
+// SetRecentVersionProduct : replace recent versions of the product
+//
+// Deprecated: This method has been deprecated in favor of indexing RecentFile by product ID and will be removed in v2.0.0
+func (p ProductDetails) SetRecentVersionProduct(recentFile *RecentFile, versions []string) {
+	if *recentFile == nil {
+		*recentFile = RecentFile{}
+	}
+	(*recentFile)[p.GetId()] = versions
+}
+
+func (p ProductDetails) GetArchiveFormat() string {
+	if p.ArchiveFormat == "" {
+		return ArchiveFormatZip
+	}
+	return p.ArchiveFormat
+}
+
+func (p ProductDetails) GetVersionsSource() string {
+	if p.VersionsSource == "" {
+		return VersionsSourceJSON
+	}
+	return p.VersionsSource
+}
+
+func (p ProductDetails) GetReleaseTagPrefix() string {
+	return p.ReleaseTagPrefix
+}
+
+// GetArtifactFileName : name of the release artifact, e.g. terraform_1.10.4_linux_amd64.zip
+func (p ProductDetails) GetArtifactFileName(archivePrefix, version, goos, goarch string) string {
+	return archivePrefix + version + "_" + goos + "_" + goarch + archiveFormatExtension(p.GetArchiveFormat())
+}
+
+// GetChecksumsFileName : name of the checksums file, e.g. terraform_1.10.4_SHA256SUMS
+func (p ProductDetails) GetChecksumsFileName(archivePrefix, version string) string {
+	return archivePrefix + version + "_SHA256SUMS"
+}
+
+// artifactURL : build URL of the release directory (releasePath) on the download mirror.
+// The mirrorURL is used instead of the product's default download mirror, if set.
+// The fallbackURL is used if neither of them is set.
+func (p ProductDetails) artifactURL(fallbackURL, mirrorURL, releasePath string) string {
+	downloadURL := fallbackURL
 
 	// Use default download mirror, if set (it should be in all cases)
 	if p.DefaultDownloadMirror != "" {
-		downloadUrl = p.DefaultDownloadMirror
+		downloadURL = p.DefaultDownloadMirror
 	}
 
 	// If the actual mirror is not the default, use this mirror for downloading
 	if mirrorURL != "" && mirrorURL != p.DefaultDownloadMirror {
-		downloadUrl = mirrorURL
+		downloadURL = mirrorURL
 	}
 
-	downloadUrl = strings.TrimRight(downloadUrl, "/")
+	downloadURL = strings.TrimRight(downloadURL, "/")
 
 	// Fail if no download URL is found (this should not happen)
-	if downloadUrl == "" {
+	if downloadURL == "" {
 		logger.Error("No download URL found")
 		return ""
 	}
 
-	if err := IsValidRemoteURL(downloadUrl); err != nil {
+	if err := IsValidRemoteURL(downloadURL); err != nil {
 		logger.Error(err)
 		return ""
 	}
 
-	return fmt.Sprintf("%s/%s", downloadUrl, version)
+	return fmt.Sprintf("%s/%s", downloadURL, releasePath)
 }
 
-func (p TerraformProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
-	var versions TerraformVersionJSON
-	err := json.Unmarshal(body, &versions)
-	if err != nil {
-		return nil, err
-	}
-	return slices.Collect(maps.Keys(versions.Versions)), nil
-}
+// Terraform Product
 
-// nolint:revive // FIXME: var-naming: method GetPublicKeyId should be GetPublicKeyID (revive)
-func (p TerraformProduct) GetPublicKeyId() string {
-	return p.PublicKeyId
+// Struct representing Terraform JSON:
+// https://releases.hashicorp.com/terraform/index.json
+type TerraformVersionJSON struct {
+	Versions map[string]struct{} `json:"versions"`
 }
 
-func (p TerraformProduct) GetPublicKeyURLs() []string {
-	return p.PublicKeyURLs
+// nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
+func (p TerraformProduct) GetArtifactUrl(mirrorURL string, version string) string {
+	// Backwards compatible with old tests: fall back to default mirror
+	return p.artifactURL(p.DefaultMirror, mirrorURL, version)
 }
 
-func (p TerraformProduct) GetPublicKeyLegacyLiteral() string {
-	return p.PublicKeyLegacyLiteral
+func (p TerraformProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
+	return getVersionsFromTerraformJSON(body)
 }
 
 func (p TerraformProduct) GetShaSignatureSuffix() string {
 	return p.GetPublicKeyId() + ".sig"
 }
 
-func (p TerraformProduct) GetRecentVersionProduct(recentFile *RecentFile) []string {
-	return recentFile.Terraform
-}
-
-func (p TerraformProduct) SetRecentVersionProduct(recentFile *RecentFile, versions []string) {
-	recentFile.Terraform = versions
-}
-
-func (p TerraformProduct) GetFileExtensions() []string {
-	return p.FileExtensions
+func getVersionsFromTerraformJSON(body []byte) ([]string, error) {
+	var versions TerraformVersionJSON
+	err := json.Unmarshal(body, &versions)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Collect(maps.Keys(versions.Versions)), nil
 }
 
 // OpenTofu methods
@@ -172,245 +272,316 @@ type OpenTofuVersionJSON struct {
 	} `json:"versions"`
 }
 
-// nolint:revive // FIXME: var-naming: method GetId should be GetID (revive)
-func (p OpenTofuProduct) GetId() string {
-	return p.ID
+// nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
+func (p OpenTofuProduct) GetArtifactUrl(mirrorURL string, version string) string {
+	return p.artifactURL("", mirrorURL, p.ReleaseTagPrefix+version)
 }
 
-func (p OpenTofuProduct) GetName() string {
-	return p.Name
+func (p OpenTofuProduct) GetShaSignatureSuffix() string {
+	return "gpgsig"
 }
 
-// nolint:revive // FIXME: var-naming: method GetDefaultMirrorUrl should be GetDefaultMirrorURL (revive)
-func (p OpenTofuProduct) GetDefaultMirrorUrl() string {
-	return p.DefaultMirror
+func (p OpenTofuProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
+	return getVersionsFromOpenTofuJSON(body)
 }
 
-func (p OpenTofuProduct) GetDefaultDownloadMirrorURL() string {
-	return p.DefaultDownloadMirror
+func getVersionsFromOpenTofuJSON(body []byte) ([]string, error) {
+	var versionsData OpenTofuVersionJSON
+	err := json.Unmarshal(body, &versionsData)
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, v := range versionsData.Versions {
+		versions = append(versions, v.ID)
+	}
+	return versions, nil
 }
 
-func (p OpenTofuProduct) GetVersionPrefix() string {
-	return p.VersionPrefix
-}
+// HashiCorp Product
+
+// Signing key shared by all products published on releases.hashicorp.com
+//
+// nolint:revive // FIXME: var-naming: const hashicorpPublicKeyId should be hashicorpPublicKeyID (revive)
+const hashicorpPublicKeyId = "72D7468F"
+
+// Platforms none of the products published on releases.hashicorp.com are built for
+var hashicorpPlatformRules = []PlatformRule{
+	{OS: "linux", Arch: "riscv64", Unavailable: true},
+}
+
+var (
+	hashicorpPublicKeyURLs          = []string{"https://www.hashicorp.com/.well-known/pgp-key.txt", "https://keybase.io/hashicorp/pgp_keys.asc"}
+	hashicorpPublicKeyLegacyLiteral = "-----BEGIN PGP PUBLIC KEY BLOCK-----\n" +
+		"Comment: https://www.hashicorp.com/.well-known/pgp-key-old.txt\n" +
+		"\n" +
+		"mQINBGB9+xkBEACabYZOWKmgZsHTdRDiyPJxhbuUiKX65GUWkyRMJKi/1dviVxOX\n" +
+		"PG6hBPtF48IFnVgxKpIb7G6NjBousAV+CuLlv5yqFKpOZEGC6sBV+Gx8Vu1CICpl\n" +
+		"Zm+HpQPcIzwBpN+Ar4l/exCG/f/MZq/oxGgH+TyRF3XcYDjG8dbJCpHO5nQ5Cy9h\n" +
+		"QIp3/Bh09kET6lk+4QlofNgHKVT2epV8iK1cXlbQe2tZtfCUtxk+pxvU0UHXp+AB\n" +
+		"0xc3/gIhjZp/dePmCOyQyGPJbp5bpO4UeAJ6frqhexmNlaw9Z897ltZmRLGq1p4a\n" +
+		"RnWL8FPkBz9SCSKXS8uNyV5oMNVn4G1obCkc106iWuKBTibffYQzq5TG8FYVJKrh\n" +
+		"RwWB6piacEB8hl20IIWSxIM3J9tT7CPSnk5RYYCTRHgA5OOrqZhC7JefudrP8n+M\n" +
+		"pxkDgNORDu7GCfAuisrf7dXYjLsxG4tu22DBJJC0c/IpRpXDnOuJN1Q5e/3VUKKW\n" +
+		"mypNumuQpP5lc1ZFG64TRzb1HR6oIdHfbrVQfdiQXpvdcFx+Fl57WuUraXRV6qfb\n" +
+		"4ZmKHX1JEwM/7tu21QE4F1dz0jroLSricZxfaCTHHWNfvGJoZ30/MZUrpSC0IfB3\n" +
+		"iQutxbZrwIlTBt+fGLtm3vDtwMFNWM+Rb1lrOxEQd2eijdxhvBOHtlIcswARAQAB\n" +
+		"tERIYXNoaUNvcnAgU2VjdXJpdHkgKGhhc2hpY29ycC5jb20vc2VjdXJpdHkpIDxz\n" +
+		"ZWN1cml0eUBoYXNoaWNvcnAuY29tPokCVAQTAQoAPhYhBMh0AR8KtAURDQIQVTQ2\n" +
+		"XZRy10aPBQJgffsZAhsDBQkJZgGABQsJCAcCBhUKCQgLAgQWAgMBAh4BAheAAAoJ\n" +
+		"EDQ2XZRy10aPtpcP/0PhJKiHtC1zREpRTrjGizoyk4Sl2SXpBZYhkdrG++abo6zs\n" +
+		"buaAG7kgWWChVXBo5E20L7dbstFK7OjVs7vAg/OLgO9dPD8n2M19rpqSbbvKYWvp\n" +
+		"0NSgvFTT7lbyDhtPj0/bzpkZEhmvQaDWGBsbDdb2dBHGitCXhGMpdP0BuuPWEix+\n" +
+		"QnUMaPwU51q9GM2guL45Tgks9EKNnpDR6ZdCeWcqo1IDmklloidxT8aKL21UOb8t\n" +
+		"cD+Bg8iPaAr73bW7Jh8TdcV6s6DBFub+xPJEB/0bVPmq3ZHs5B4NItroZ3r+h3ke\n" +
+		"VDoSOSIZLl6JtVooOJ2la9ZuMqxchO3mrXLlXxVCo6cGcSuOmOdQSz4OhQE5zBxx\n" +
+		"LuzA5ASIjASSeNZaRnffLIHmht17BPslgNPtm6ufyOk02P5XXwa69UCjA3RYrA2P\n" +
+		"QNNC+OWZ8qQLnzGldqE4MnRNAxRxV6cFNzv14ooKf7+k686LdZrP/3fQu2p3k5rY\n" +
+		"0xQUXKh1uwMUMtGR867ZBYaxYvwqDrg9XB7xi3N6aNyNQ+r7zI2lt65lzwG1v9hg\n" +
+		"FG2AHrDlBkQi/t3wiTS3JOo/GCT8BjN0nJh0lGaRFtQv2cXOQGVRW8+V/9IpqEJ1\n" +
+		"qQreftdBFWxvH7VJq2mSOXUJyRsoUrjkUuIivaA9Ocdipk2CkP8bpuGz7ZF4uQIN\n" +
+		"BGB9+xkBEACoklYsfvWRCjOwS8TOKBTfl8myuP9V9uBNbyHufzNETbhYeT33Cj0M\n" +
+		"GCNd9GdoaknzBQLbQVSQogA+spqVvQPz1MND18GIdtmr0BXENiZE7SRvu76jNqLp\n" +
+		"KxYALoK2Pc3yK0JGD30HcIIgx+lOofrVPA2dfVPTj1wXvm0rbSGA4Wd4Ng3d2AoR\n" +
+		"G/wZDAQ7sdZi1A9hhfugTFZwfqR3XAYCk+PUeoFrkJ0O7wngaon+6x2GJVedVPOs\n" +
+		"2x/XOR4l9ytFP3o+5ILhVnsK+ESVD9AQz2fhDEU6RhvzaqtHe+sQccR3oVLoGcat\n" +
+		"ma5rbfzH0Fhj0JtkbP7WreQf9udYgXxVJKXLQFQgel34egEGG+NlbGSPG+qHOZtY\n" +
+		"4uWdlDSvmo+1P95P4VG/EBteqyBbDDGDGiMs6lAMg2cULrwOsbxWjsWka8y2IN3z\n" +
+		"1stlIJFvW2kggU+bKnQ+sNQnclq3wzCJjeDBfucR3a5WRojDtGoJP6Fc3luUtS7V\n" +
+		"5TAdOx4dhaMFU9+01OoH8ZdTRiHZ1K7RFeAIslSyd4iA/xkhOhHq89F4ECQf3Bt4\n" +
+		"ZhGsXDTaA/VgHmf3AULbrC94O7HNqOvTWzwGiWHLfcxXQsr+ijIEQvh6rHKmJK8R\n" +
+		"9NMHqc3L18eMO6bqrzEHW0Xoiu9W8Yj+WuB3IKdhclT3w0pO4Pj8gQARAQABiQI8\n" +
+		"BBgBCgAmFiEEyHQBHwq0BRENAhBVNDZdlHLXRo8FAmB9+xkCGwwFCQlmAYAACgkQ\n" +
+		"NDZdlHLXRo9ZnA/7BmdpQLeTjEiXEJyW46efxlV1f6THn9U50GWcE9tebxCXgmQf\n" +
+		"u+Uju4hreltx6GDi/zbVVV3HCa0yaJ4JVvA4LBULJVe3ym6tXXSYaOfMdkiK6P1v\n" +
+		"JgfpBQ/b/mWB0yuWTUtWx18BQQwlNEQWcGe8n1lBbYsH9g7QkacRNb8tKUrUbWlQ\n" +
+		"QsU8wuFgly22m+Va1nO2N5C/eE/ZEHyN15jEQ+QwgQgPrK2wThcOMyNMQX/VNEr1\n" +
+		"Y3bI2wHfZFjotmek3d7ZfP2VjyDudnmCPQ5xjezWpKbN1kvjO3as2yhcVKfnvQI5\n" +
+		"P5Frj19NgMIGAp7X6pF5Csr4FX/Vw316+AFJd9Ibhfud79HAylvFydpcYbvZpScl\n" +
+		"7zgtgaXMCVtthe3GsG4gO7IdxxEBZ/Fm4NLnmbzCIWOsPMx/FxH06a539xFq/1E2\n" +
+		"1nYFjiKg8a5JFmYU/4mV9MQs4bP/3ip9byi10V+fEIfp5cEEmfNeVeW5E7J8PqG9\n" +
+		"t4rLJ8FR4yJgQUa2gs2SNYsjWQuwS/MJvAv4fDKlkQjQmYRAOp1SszAnyaplvri4\n" +
+		"ncmfDsf0r65/sd6S40g5lHH8LIbGxcOIN6kwthSTPWX89r42CbY8GzjTkaeejNKx\n" +
+		"v1aCrO58wAtursO1DiXCvBY7+NdafMRnoHwBk50iPqrVkNA8fv+auRyB2/G5Ag0E\n" +
+		"YH3+JQEQALivllTjMolxUW2OxrXb+a2Pt6vjCBsiJzrUj0Pa63U+lT9jldbCCfgP\n" +
+		"wDpcDuO1O05Q8k1MoYZ6HddjWnqKG7S3eqkV5c3ct3amAXp513QDKZUfIDylOmhU\n" +
+		"qvxjEgvGjdRjz6kECFGYr6Vnj/p6AwWv4/FBRFlrq7cnQgPynbIH4hrWvewp3Tqw\n" +
+		"GVgqm5RRofuAugi8iZQVlAiQZJo88yaztAQ/7VsXBiHTn61ugQ8bKdAsr8w/ZZU5\n" +
+		"HScHLqRolcYg0cKN91c0EbJq9k1LUC//CakPB9mhi5+aUVUGusIM8ECShUEgSTCi\n" +
+		"KQiJUPZ2CFbbPE9L5o9xoPCxjXoX+r7L/WyoCPTeoS3YRUMEnWKvc42Yxz3meRb+\n" +
+		"BmaqgbheNmzOah5nMwPupJYmHrjWPkX7oyyHxLSFw4dtoP2j6Z7GdRXKa2dUYdk2\n" +
+		"x3JYKocrDoPHh3Q0TAZujtpdjFi1BS8pbxYFb3hHmGSdvz7T7KcqP7ChC7k2RAKO\n" +
+		"GiG7QQe4NX3sSMgweYpl4OwvQOn73t5CVWYp/gIBNZGsU3Pto8g27vHeWyH9mKr4\n" +
+		"cSepDhw+/X8FGRNdxNfpLKm7Vc0Sm9Sof8TRFrBTqX+vIQupYHRi5QQCuYaV6OVr\n" +
+		"ITeegNK3So4m39d6ajCR9QxRbmjnx9UcnSYYDmIB6fpBuwT0ogNtABEBAAGJBHIE\n" +
+		"GAEKACYCGwIWIQTIdAEfCrQFEQ0CEFU0Nl2UctdGjwUCYH4bgAUJAeFQ2wJAwXQg\n" +
+		"BBkBCgAdFiEEs2y6kaLAcwxDX8KAsLRBCXaFtnYFAmB9/iUACgkQsLRBCXaFtnYX\n" +
+		"BhAAlxejyFXoQwyGo9U+2g9N6LUb/tNtH29RHYxy4A3/ZUY7d/FMkArmh4+dfjf0\n" +
+		"p9MJz98Zkps20kaYP+2YzYmaizO6OA6RIddcEXQDRCPHmLts3097mJ/skx9qLAf6\n" +
+		"rh9J7jWeSqWO6VW6Mlx8j9m7sm3Ae1OsjOx/m7lGZOhY4UYfY627+Jf7WQ5103Qs\n" +
+		"lgQ09es/vhTCx0g34SYEmMW15Tc3eCjQ21b1MeJD/V26npeakV8iCZ1kHZHawPq/\n" +
+		"aCCuYEcCeQOOteTWvl7HXaHMhHIx7jjOd8XX9V+UxsGz2WCIxX/j7EEEc7CAxwAN\n" +
+		"nWp9jXeLfxYfjrUB7XQZsGCd4EHHzUyCf7iRJL7OJ3tz5Z+rOlNjSgci+ycHEccL\n" +
+		"YeFAEV+Fz+sj7q4cFAferkr7imY1XEI0Ji5P8p/uRYw/n8uUf7LrLw5TzHmZsTSC\n" +
+		"UaiL4llRzkDC6cVhYfqQWUXDd/r385OkE4oalNNE+n+txNRx92rpvXWZ5qFYfv7E\n" +
+		"95fltvpXc0iOugPMzyof3lwo3Xi4WZKc1CC/jEviKTQhfn3WZukuF5lbz3V1PQfI\n" +
+		"xFsYe9WYQmp25XGgezjXzp89C/OIcYsVB1KJAKihgbYdHyUN4fRCmOszmOUwEAKR\n" +
+		"3k5j4X8V5bk08sA69NVXPn2ofxyk3YYOMYWW8ouObnXoS8QJEDQ2XZRy10aPMpsQ\n" +
+		"AIbwX21erVqUDMPn1uONP6o4NBEq4MwG7d+fT85rc1U0RfeKBwjucAE/iStZDQoM\n" +
+		"ZKWvGhFR+uoyg1LrXNKuSPB82unh2bpvj4zEnJsJadiwtShTKDsikhrfFEK3aCK8\n" +
+		"Zuhpiu3jxMFDhpFzlxsSwaCcGJqcdwGhWUx0ZAVD2X71UCFoOXPjF9fNnpy80YNp\n" +
+		"flPjj2RnOZbJyBIM0sWIVMd8F44qkTASf8K5Qb47WFN5tSpePq7OCm7s8u+lYZGK\n" +
+		"wR18K7VliundR+5a8XAOyUXOL5UsDaQCK4Lj4lRaeFXunXl3DJ4E+7BKzZhReJL6\n" +
+		"EugV5eaGonA52TWtFdB8p+79wPUeI3KcdPmQ9Ll5Zi/jBemY4bzasmgKzNeMtwWP\n" +
+		"fk6WgrvBwptqohw71HDymGxFUnUP7XYYjic2sVKhv9AevMGycVgwWBiWroDCQ9Ja\n" +
+		"btKfxHhI2p+g+rcywmBobWJbZsujTNjhtme+kNn1mhJsD3bKPjKQfAxaTskBLb0V\n" +
+		"wgV21891TS1Dq9kdPLwoS4XNpYg2LLB4p9hmeG3fu9+OmqwY5oKXsHiWc43dei9Y\n" +
+		"yxZ1AAUOIaIdPkq+YG/PhlGE4YcQZ4RPpltAr0HfGgZhmXWigbGS+66pUj+Ojysc\n" +
+		"j0K5tCVxVu0fhhFpOlHv0LWaxCbnkgkQH9jfMEJkAWMOuQINBGCAXCYBEADW6RNr\n" +
+		"ZVGNXvHVBqSiOWaxl1XOiEoiHPt50Aijt25yXbG+0kHIFSoR+1g6Lh20JTCChgfQ\n" +
+		"kGGjzQvEuG1HTw07YhsvLc0pkjNMfu6gJqFox/ogc53mz69OxXauzUQ/TZ27GDVp\n" +
+		"UBu+EhDKt1s3OtA6Bjz/csop/Um7gT0+ivHyvJ/jGdnPEZv8tNuSE/Uo+hn/Q9hg\n" +
+		"8SbveZzo3C+U4KcabCESEFl8Gq6aRi9vAfa65oxD5jKaIz7cy+pwb0lizqlW7H9t\n" +
+		"Qlr3dBfdIcdzgR55hTFC5/XrcwJ6/nHVH/xGskEasnfCQX8RYKMuy0UADJy72TkZ\n" +
+		"bYaCx+XXIcVB8GTOmJVoAhrTSSVLAZspfCnjwnSxisDn3ZzsYrq3cV6sU8b+QlIX\n" +
+		"7VAjurE+5cZiVlaxgCjyhKqlGgmonnReWOBacCgL/UvuwMmMp5TTLmiLXLT7uxeG\n" +
+		"ojEyoCk4sMrqrU1jevHyGlDJH9Taux15GILDwnYFfAvPF9WCid4UZ4Ouwjcaxfys\n" +
+		"3LxNiZIlUsXNKwS3mhiMRL4TRsbs4k4QE+LIMOsauIvcvm8/frydvQ/kUwIhVTH8\n" +
+		"0XGOH909bYtJvY3fudK7ShIwm7ZFTduBJUG473E/Fn3VkhTmBX6+PjOC50HR/Hyb\n" +
+		"waRCzfDruMe3TAcE/tSP5CUOb9C7+P+hPzQcDwARAQABiQRyBBgBCgAmFiEEyHQB\n" +
+		"Hwq0BRENAhBVNDZdlHLXRo8FAmCAXCYCGwIFCQlmAYACQAkQNDZdlHLXRo/BdCAE\n" +
+		"GQEKAB0WIQQ3TsdbSFkTYEqDHMfIIMbVzSerhwUCYIBcJgAKCRDIIMbVzSerh0Xw\n" +
+		"D/9ghnUsoNCu1OulcoJdHboMazJvDt/znttdQSnULBVElgM5zk0Uyv87zFBzuCyQ\n" +
+		"JWL3bWesQ2uFx5fRWEPDEfWVdDrjpQGb1OCCQyz1QlNPV/1M1/xhKGS9EeXrL8Dw\n" +
+		"F6KTGkRwn1yXiP4BGgfeFIQHmJcKXEZ9HkrpNb8mcexkROv4aIPAwn+IaE+NHVtt\n" +
+		"IBnufMXLyfpkWJQtJa9elh9PMLlHHnuvnYLvuAoOkhuvs7fXDMpfFZ01C+QSv1dz\n" +
+		"Hm52GSStERQzZ51w4c0rYDneYDniC/sQT1x3dP5Xf6wzO+EhRMabkvoTbMqPsTEP\n" +
+		"xyWr2pNtTBYp7pfQjsHxhJpQF0xjGN9C39z7f3gJG8IJhnPeulUqEZjhRFyVZQ6/\n" +
+		"siUeq7vu4+dM/JQL+i7KKe7Lp9UMrG6NLMH+ltaoD3+lVm8fdTUxS5MNPoA/I8cK\n" +
+		"1OWTJHkrp7V/XaY7mUtvQn5V1yET5b4bogz4nME6WLiFMd+7x73gB+YJ6MGYNuO8\n" +
+		"e/NFK67MfHbk1/AiPTAJ6s5uHRQIkZcBPG7y5PpfcHpIlwPYCDGYlTajZXblyKrw\n" +
+		"BttVnYKvKsnlysv11glSg0DphGxQJbXzWpvBNyhMNH5dffcfvd3eXJAxnD81GD2z\n" +
+		"ZAriMJ4Av2TfeqQ2nxd2ddn0jX4WVHtAvLXfCgLM2Gveho4jD/9sZ6PZz/rEeTvt\n" +
+		"h88t50qPcBa4bb25X0B5FO3TeK2LL3VKLuEp5lgdcHVonrcdqZFobN1CgGJua8TW\n" +
+		"SprIkh+8ATZ/FXQTi01NzLhHXT1IQzSpFaZw0gb2f5ruXwvTPpfXzQrs2omY+7s7\n" +
+		"fkCwGPesvpSXPKn9v8uhUwD7NGW/Dm+jUM+QtC/FqzX7+/Q+OuEPjClUh1cqopCZ\n" +
+		"EvAI3HjnavGrYuU6DgQdjyGT/UDbuwbCXqHxHojVVkISGzCTGpmBcQYQqhcFRedJ\n" +
+		"yJlu6PSXlA7+8Ajh52oiMJ3ez4xSssFgUQAyOB16432tm4erpGmCyakkoRmMUn3p\n" +
+		"wx+QIppxRlsHznhcCQKR3tcblUqH3vq5i4/ZAihusMCa0YrShtxfdSb13oKX+pFr\n" +
+		"aZXvxyZlCa5qoQQBV1sowmPL1N2j3dR9TVpdTyCFQSv4KeiExmowtLIjeCppRBEK\n" +
+		"eeYHJnlfkyKXPhxTVVO6H+dU4nVu0ASQZ07KiQjbI+zTpPKFLPp3/0sPRJM57r1+\n" +
+		"aTS71iR7nZNZ1f8LZV2OvGE6fJVtgJ1J4Nu02K54uuIhU3tg1+7Xt+IqwRc9rbVr\n" +
+		"pHH/hFCYBPW2D2dxB+k2pQlg5NI+TpsXj5Zun8kRw5RtVb+dLuiH/xmxArIee8Jq\n" +
+		"ZF5q4h4I33PSGDdSvGXn9UMY5Isjpg==\n" +
+		"=7pIB\n" +
+		"-----END PGP PUBLIC KEY BLOCK-----\n"
+)
 
-func (p OpenTofuProduct) GetExecutableName() string {
-	return p.ExecutableName
+// HashiCorpProduct : any product released on https://releases.hashicorp.com
+// (same versions JSON, archive layout, checksums and signing key as Terraform)
+type HashiCorpProduct struct {
+	ProductDetails
 }
 
-func (p OpenTofuProduct) GetArchivePrefix() string {
-	return p.ArchivePrefix
+// newHashiCorpProduct : build HashiCorp product from its ID (also the executable name)
+func newHashiCorpProduct(id string, name string) HashiCorpProduct {
+	return HashiCorpProduct{
+		ProductDetails{
+			ID:                     id,
+			Name:                   name,
+			DefaultMirror:          fmt.Sprintf("https://releases.hashicorp.com/%s/index.json", id),
+			DefaultDownloadMirror:  "https://releases.hashicorp.com/" + id,
+			VersionPrefix:          id + "_",
+			ExecutableName:         id,
+			ArchivePrefix:          id + "_",
+			PublicKeyId:            hashicorpPublicKeyId,
+			PublicKeyURLs:          hashicorpPublicKeyURLs,
+			PublicKeyLegacyLiteral: hashicorpPublicKeyLegacyLiteral,
+			VersionFileNames:       []string{"." + id + "-version"},
+			PlatformRules:          hashicorpPlatformRules,
+		},
+	}
 }
 
 // nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
-func (p OpenTofuProduct) GetArtifactUrl(mirrorURL string, version string) string {
-	var downloadUrl string
-
-	// Use default download mirror, if set (it should be in all cases)
-	if p.DefaultDownloadMirror != "" {
-		downloadUrl = p.DefaultDownloadMirror
-	}
+func (p HashiCorpProduct) GetArtifactUrl(mirrorURL string, version string) string {
+	return p.artifactURL("", mirrorURL, version)
+}
 
-	// If the actual mirror is not the default, use this mirror for downloading
-	if mirrorURL != "" && mirrorURL != p.DefaultDownloadMirror {
-		downloadUrl = mirrorURL
-	}
+func (p HashiCorpProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
+	return getVersionsFromTerraformJSON(body)
+}
 
-	downloadUrl = strings.TrimRight(downloadUrl, "/")
+func (p HashiCorpProduct) GetShaSignatureSuffix() string {
+	return p.GetPublicKeyId() + ".sig"
+}
 
-	// Fail if no download URL is found (this should not happen)
-	if downloadUrl == "" {
-		logger.Error("No download URL found")
-		return ""
-	}
+// Terragrunt Product
 
-	if err := IsValidRemoteURL(downloadUrl); err != nil {
-		logger.Error(err)
-		return ""
-	}
-
-	return fmt.Sprintf("%s/v%s", downloadUrl, version)
+// TerragruntProduct : Terragrunt is released on GitHub as uncompressed executables
+// (e.g. terragrunt_linux_amd64) with a single SHA256SUMS file per release
+type TerragruntProduct struct {
+	ProductDetails
 }
 
-// nolint:revive // FIXME: var-naming: method GetPublicKeyId should be GetPublicKeyID (revive)
-func (p OpenTofuProduct) GetPublicKeyId() string {
-	return p.PublicKeyId
+// nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
+func (p TerragruntProduct) GetArtifactUrl(mirrorURL string, version string) string {
+	return p.artifactURL("", mirrorURL, p.ReleaseTagPrefix+version)
 }
 
-func (p OpenTofuProduct) GetPublicKeyURLs() []string {
-	return p.PublicKeyURLs
+func (p TerragruntProduct) GetArtifactFileName(archivePrefix, _, goos, goarch string) string {
+	fileName := archivePrefix + goos + "_" + goarch
+	if goos == windows {
+		fileName += ".exe"
+	}
+	return fileName
 }
 
-func (p OpenTofuProduct) GetPublicKeyLegacyLiteral() string {
-	return p.PublicKeyLegacyLiteral
+func (p TerragruntProduct) GetChecksumsFileName(_, _ string) string {
+	return "SHA256SUMS"
 }
 
-func (p OpenTofuProduct) GetShaSignatureSuffix() string {
-	return "gpgsig"
+// Terragrunt checksums are not PGP-signed
+func (p TerragruntProduct) GetShaSignatureSuffix() string {
+	return ""
 }
 
-func (p OpenTofuProduct) GetRecentVersionProduct(recentFile *RecentFile) []string {
-	return recentFile.OpenTofu
+func (p TerragruntProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
+	return getVersionsFromGitHubReleasesJSON(body, p.ReleaseTagPrefix)
 }
 
-func (p OpenTofuProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
-	var versionsData OpenTofuVersionJSON
-	err := json.Unmarshal(body, &versionsData)
+func getVersionsFromGitHubReleasesJSON(body []byte, tagPrefix string) ([]string, error) {
+	var releases []GitHubRelease
+	err := json.Unmarshal(body, &releases)
 	if err != nil {
 		return nil, err
 	}
-	var versions []string
-	for _, v := range versionsData.Versions {
-		versions = append(versions, v.ID)
-	}
-	return versions, nil
+	return getVersionsFromGitHubReleases(releases, tagPrefix, true), nil
+}
+
+// Custom (configuration-declared) Product
+
+// Shapes of the versions JSON served by the mirror of a custom product
+const (
+	VersionsFormatTerraform      = "terraform"       // https://releases.hashicorp.com/terraform/index.json
+	VersionsFormatOpenTofu       = "opentofu"        // https://get.opentofu.org/tofu/api.json
+	VersionsFormatGitHubReleases = "github-releases" // https://api.github.com/repos/opentofu/opentofu/releases
+)
+
+// CustomProduct : product declared in the configuration file rather than built into tfswitch
+type CustomProduct struct {
+	ProductDetails
+	// Shape of the versions JSON served by DefaultMirror: one of VersionsFormat* constants
+	VersionsFormat string
+	// Suffix of the checksums signature file. Defaults to "<PublicKeyId>.sig"
+	ShaSignatureSuffix string
 }
 
-func (p OpenTofuProduct) SetRecentVersionProduct(recentFile *RecentFile, versions []string) {
-	recentFile.OpenTofu = versions
+// nolint:revive // FIXME: var-naming: method GetArtifactUrl should be GetArtifactURL (revive)
+func (p CustomProduct) GetArtifactUrl(mirrorURL string, version string) string {
+	return p.artifactURL("", mirrorURL, p.ReleaseTagPrefix+version)
+}
+
+func (p CustomProduct) GetVersionsFromJSON(body []byte) ([]string, error) {
+	switch p.VersionsFormat {
+	case VersionsFormatOpenTofu:
+		return getVersionsFromOpenTofuJSON(body)
+	case VersionsFormatGitHubReleases:
+		return getVersionsFromGitHubReleasesJSON(body, p.ReleaseTagPrefix)
+	case VersionsFormatTerraform, "":
+		return getVersionsFromTerraformJSON(body)
+	default:
+		return nil, fmt.Errorf("unsupported versions format %q of %s product", p.VersionsFormat, p.GetName())
+	}
 }
 
-func (p OpenTofuProduct) GetFileExtensions() []string {
-	return p.FileExtensions
+func (p CustomProduct) GetShaSignatureSuffix() string {
+	if p.ShaSignatureSuffix != "" {
+		return p.ShaSignatureSuffix
+	}
+	return p.GetPublicKeyId() + ".sig"
 }
 
 // Factory methods
 var products = []Product{
 	TerraformProduct{
 		ProductDetails{
-			ID:                    "terraform",
-			Name:                  "Terraform",
-			DefaultMirror:         "https://releases.hashicorp.com/terraform/index.json",
-			DefaultDownloadMirror: "https://releases.hashicorp.com/terraform",
-			VersionPrefix:         "terraform_",
-			ExecutableName:        "terraform",
-			ArchivePrefix:         "terraform_",
-			PublicKeyId:           "72D7468F",
-			PublicKeyURLs:         []string{"https://www.hashicorp.com/.well-known/pgp-key.txt", "https://keybase.io/hashicorp/pgp_keys.asc"},
-			PublicKeyLegacyLiteral: "-----BEGIN PGP PUBLIC KEY BLOCK-----\n" +
-				"Comment: https://www.hashicorp.com/.well-known/pgp-key-old.txt\n" +
-				"\n" +
-				"mQINBGB9+xkBEACabYZOWKmgZsHTdRDiyPJxhbuUiKX65GUWkyRMJKi/1dviVxOX\n" +
-				"PG6hBPtF48IFnVgxKpIb7G6NjBousAV+CuLlv5yqFKpOZEGC6sBV+Gx8Vu1CICpl\n" +
-				"Zm+HpQPcIzwBpN+Ar4l/exCG/f/MZq/oxGgH+TyRF3XcYDjG8dbJCpHO5nQ5Cy9h\n" +
-				"QIp3/Bh09kET6lk+4QlofNgHKVT2epV8iK1cXlbQe2tZtfCUtxk+pxvU0UHXp+AB\n" +
-				"0xc3/gIhjZp/dePmCOyQyGPJbp5bpO4UeAJ6frqhexmNlaw9Z897ltZmRLGq1p4a\n" +
-				"RnWL8FPkBz9SCSKXS8uNyV5oMNVn4G1obCkc106iWuKBTibffYQzq5TG8FYVJKrh\n" +
-				"RwWB6piacEB8hl20IIWSxIM3J9tT7CPSnk5RYYCTRHgA5OOrqZhC7JefudrP8n+M\n" +
-				"pxkDgNORDu7GCfAuisrf7dXYjLsxG4tu22DBJJC0c/IpRpXDnOuJN1Q5e/3VUKKW\n" +
-				"mypNumuQpP5lc1ZFG64TRzb1HR6oIdHfbrVQfdiQXpvdcFx+Fl57WuUraXRV6qfb\n" +
-				"4ZmKHX1JEwM/7tu21QE4F1dz0jroLSricZxfaCTHHWNfvGJoZ30/MZUrpSC0IfB3\n" +
-				"iQutxbZrwIlTBt+fGLtm3vDtwMFNWM+Rb1lrOxEQd2eijdxhvBOHtlIcswARAQAB\n" +
-				"tERIYXNoaUNvcnAgU2VjdXJpdHkgKGhhc2hpY29ycC5jb20vc2VjdXJpdHkpIDxz\n" +
-				"ZWN1cml0eUBoYXNoaWNvcnAuY29tPokCVAQTAQoAPhYhBMh0AR8KtAURDQIQVTQ2\n" +
-				"XZRy10aPBQJgffsZAhsDBQkJZgGABQsJCAcCBhUKCQgLAgQWAgMBAh4BAheAAAoJ\n" +
-				"EDQ2XZRy10aPtpcP/0PhJKiHtC1zREpRTrjGizoyk4Sl2SXpBZYhkdrG++abo6zs\n" +
-				"buaAG7kgWWChVXBo5E20L7dbstFK7OjVs7vAg/OLgO9dPD8n2M19rpqSbbvKYWvp\n" +
-				"0NSgvFTT7lbyDhtPj0/bzpkZEhmvQaDWGBsbDdb2dBHGitCXhGMpdP0BuuPWEix+\n" +
-				"QnUMaPwU51q9GM2guL45Tgks9EKNnpDR6ZdCeWcqo1IDmklloidxT8aKL21UOb8t\n" +
-				"cD+Bg8iPaAr73bW7Jh8TdcV6s6DBFub+xPJEB/0bVPmq3ZHs5B4NItroZ3r+h3ke\n" +
-				"VDoSOSIZLl6JtVooOJ2la9ZuMqxchO3mrXLlXxVCo6cGcSuOmOdQSz4OhQE5zBxx\n" +
-				"LuzA5ASIjASSeNZaRnffLIHmht17BPslgNPtm6ufyOk02P5XXwa69UCjA3RYrA2P\n" +
-				"QNNC+OWZ8qQLnzGldqE4MnRNAxRxV6cFNzv14ooKf7+k686LdZrP/3fQu2p3k5rY\n" +
-				"0xQUXKh1uwMUMtGR867ZBYaxYvwqDrg9XB7xi3N6aNyNQ+r7zI2lt65lzwG1v9hg\n" +
-				"FG2AHrDlBkQi/t3wiTS3JOo/GCT8BjN0nJh0lGaRFtQv2cXOQGVRW8+V/9IpqEJ1\n" +
-				"qQreftdBFWxvH7VJq2mSOXUJyRsoUrjkUuIivaA9Ocdipk2CkP8bpuGz7ZF4uQIN\n" +
-				"BGB9+xkBEACoklYsfvWRCjOwS8TOKBTfl8myuP9V9uBNbyHufzNETbhYeT33Cj0M\n" +
-				"GCNd9GdoaknzBQLbQVSQogA+spqVvQPz1MND18GIdtmr0BXENiZE7SRvu76jNqLp\n" +
-				"KxYALoK2Pc3yK0JGD30HcIIgx+lOofrVPA2dfVPTj1wXvm0rbSGA4Wd4Ng3d2AoR\n" +
-				"G/wZDAQ7sdZi1A9hhfugTFZwfqR3XAYCk+PUeoFrkJ0O7wngaon+6x2GJVedVPOs\n" +
-				"2x/XOR4l9ytFP3o+5ILhVnsK+ESVD9AQz2fhDEU6RhvzaqtHe+sQccR3oVLoGcat\n" +
-				"ma5rbfzH0Fhj0JtkbP7WreQf9udYgXxVJKXLQFQgel34egEGG+NlbGSPG+qHOZtY\n" +
-				"4uWdlDSvmo+1P95P4VG/EBteqyBbDDGDGiMs6lAMg2cULrwOsbxWjsWka8y2IN3z\n" +
-				"1stlIJFvW2kggU+bKnQ+sNQnclq3wzCJjeDBfucR3a5WRojDtGoJP6Fc3luUtS7V\n" +
-				"5TAdOx4dhaMFU9+01OoH8ZdTRiHZ1K7RFeAIslSyd4iA/xkhOhHq89F4ECQf3Bt4\n" +
-				"ZhGsXDTaA/VgHmf3AULbrC94O7HNqOvTWzwGiWHLfcxXQsr+ijIEQvh6rHKmJK8R\n" +
-				"9NMHqc3L18eMO6bqrzEHW0Xoiu9W8Yj+WuB3IKdhclT3w0pO4Pj8gQARAQABiQI8\n" +
-				"BBgBCgAmFiEEyHQBHwq0BRENAhBVNDZdlHLXRo8FAmB9+xkCGwwFCQlmAYAACgkQ\n" +
-				"NDZdlHLXRo9ZnA/7BmdpQLeTjEiXEJyW46efxlV1f6THn9U50GWcE9tebxCXgmQf\n" +
-				"u+Uju4hreltx6GDi/zbVVV3HCa0yaJ4JVvA4LBULJVe3ym6tXXSYaOfMdkiK6P1v\n" +
-				"JgfpBQ/b/mWB0yuWTUtWx18BQQwlNEQWcGe8n1lBbYsH9g7QkacRNb8tKUrUbWlQ\n" +
-				"QsU8wuFgly22m+Va1nO2N5C/eE/ZEHyN15jEQ+QwgQgPrK2wThcOMyNMQX/VNEr1\n" +
-				"Y3bI2wHfZFjotmek3d7ZfP2VjyDudnmCPQ5xjezWpKbN1kvjO3as2yhcVKfnvQI5\n" +
-				"P5Frj19NgMIGAp7X6pF5Csr4FX/Vw316+AFJd9Ibhfud79HAylvFydpcYbvZpScl\n" +
-				"7zgtgaXMCVtthe3GsG4gO7IdxxEBZ/Fm4NLnmbzCIWOsPMx/FxH06a539xFq/1E2\n" +
-				"1nYFjiKg8a5JFmYU/4mV9MQs4bP/3ip9byi10V+fEIfp5cEEmfNeVeW5E7J8PqG9\n" +
-				"t4rLJ8FR4yJgQUa2gs2SNYsjWQuwS/MJvAv4fDKlkQjQmYRAOp1SszAnyaplvri4\n" +
-				"ncmfDsf0r65/sd6S40g5lHH8LIbGxcOIN6kwthSTPWX89r42CbY8GzjTkaeejNKx\n" +
-				"v1aCrO58wAtursO1DiXCvBY7+NdafMRnoHwBk50iPqrVkNA8fv+auRyB2/G5Ag0E\n" +
-				"YH3+JQEQALivllTjMolxUW2OxrXb+a2Pt6vjCBsiJzrUj0Pa63U+lT9jldbCCfgP\n" +
-				"wDpcDuO1O05Q8k1MoYZ6HddjWnqKG7S3eqkV5c3ct3amAXp513QDKZUfIDylOmhU\n" +
-				"qvxjEgvGjdRjz6kECFGYr6Vnj/p6AwWv4/FBRFlrq7cnQgPynbIH4hrWvewp3Tqw\n" +
-				"GVgqm5RRofuAugi8iZQVlAiQZJo88yaztAQ/7VsXBiHTn61ugQ8bKdAsr8w/ZZU5\n" +
-				"HScHLqRolcYg0cKN91c0EbJq9k1LUC//CakPB9mhi5+aUVUGusIM8ECShUEgSTCi\n" +
-				"KQiJUPZ2CFbbPE9L5o9xoPCxjXoX+r7L/WyoCPTeoS3YRUMEnWKvc42Yxz3meRb+\n" +
-				"BmaqgbheNmzOah5nMwPupJYmHrjWPkX7oyyHxLSFw4dtoP2j6Z7GdRXKa2dUYdk2\n" +
-				"x3JYKocrDoPHh3Q0TAZujtpdjFi1BS8pbxYFb3hHmGSdvz7T7KcqP7ChC7k2RAKO\n" +
-				"GiG7QQe4NX3sSMgweYpl4OwvQOn73t5CVWYp/gIBNZGsU3Pto8g27vHeWyH9mKr4\n" +
-				"cSepDhw+/X8FGRNdxNfpLKm7Vc0Sm9Sof8TRFrBTqX+vIQupYHRi5QQCuYaV6OVr\n" +
-				"ITeegNK3So4m39d6ajCR9QxRbmjnx9UcnSYYDmIB6fpBuwT0ogNtABEBAAGJBHIE\n" +
-				"GAEKACYCGwIWIQTIdAEfCrQFEQ0CEFU0Nl2UctdGjwUCYH4bgAUJAeFQ2wJAwXQg\n" +
-				"BBkBCgAdFiEEs2y6kaLAcwxDX8KAsLRBCXaFtnYFAmB9/iUACgkQsLRBCXaFtnYX\n" +
-				"BhAAlxejyFXoQwyGo9U+2g9N6LUb/tNtH29RHYxy4A3/ZUY7d/FMkArmh4+dfjf0\n" +
-				"p9MJz98Zkps20kaYP+2YzYmaizO6OA6RIddcEXQDRCPHmLts3097mJ/skx9qLAf6\n" +
-				"rh9J7jWeSqWO6VW6Mlx8j9m7sm3Ae1OsjOx/m7lGZOhY4UYfY627+Jf7WQ5103Qs\n" +
-				"lgQ09es/vhTCx0g34SYEmMW15Tc3eCjQ21b1MeJD/V26npeakV8iCZ1kHZHawPq/\n" +
-				"aCCuYEcCeQOOteTWvl7HXaHMhHIx7jjOd8XX9V+UxsGz2WCIxX/j7EEEc7CAxwAN\n" +
-				"nWp9jXeLfxYfjrUB7XQZsGCd4EHHzUyCf7iRJL7OJ3tz5Z+rOlNjSgci+ycHEccL\n" +
-				"YeFAEV+Fz+sj7q4cFAferkr7imY1XEI0Ji5P8p/uRYw/n8uUf7LrLw5TzHmZsTSC\n" +
-				"UaiL4llRzkDC6cVhYfqQWUXDd/r385OkE4oalNNE+n+txNRx92rpvXWZ5qFYfv7E\n" +
-				"95fltvpXc0iOugPMzyof3lwo3Xi4WZKc1CC/jEviKTQhfn3WZukuF5lbz3V1PQfI\n" +
-				"xFsYe9WYQmp25XGgezjXzp89C/OIcYsVB1KJAKihgbYdHyUN4fRCmOszmOUwEAKR\n" +
-				"3k5j4X8V5bk08sA69NVXPn2ofxyk3YYOMYWW8ouObnXoS8QJEDQ2XZRy10aPMpsQ\n" +
-				"AIbwX21erVqUDMPn1uONP6o4NBEq4MwG7d+fT85rc1U0RfeKBwjucAE/iStZDQoM\n" +
-				"ZKWvGhFR+uoyg1LrXNKuSPB82unh2bpvj4zEnJsJadiwtShTKDsikhrfFEK3aCK8\n" +
-				"Zuhpiu3jxMFDhpFzlxsSwaCcGJqcdwGhWUx0ZAVD2X71UCFoOXPjF9fNnpy80YNp\n" +
-				"flPjj2RnOZbJyBIM0sWIVMd8F44qkTASf8K5Qb47WFN5tSpePq7OCm7s8u+lYZGK\n" +
-				"wR18K7VliundR+5a8XAOyUXOL5UsDaQCK4Lj4lRaeFXunXl3DJ4E+7BKzZhReJL6\n" +
-				"EugV5eaGonA52TWtFdB8p+79wPUeI3KcdPmQ9Ll5Zi/jBemY4bzasmgKzNeMtwWP\n" +
-				"fk6WgrvBwptqohw71HDymGxFUnUP7XYYjic2sVKhv9AevMGycVgwWBiWroDCQ9Ja\n" +
-				"btKfxHhI2p+g+rcywmBobWJbZsujTNjhtme+kNn1mhJsD3bKPjKQfAxaTskBLb0V\n" +
-				"wgV21891TS1Dq9kdPLwoS4XNpYg2LLB4p9hmeG3fu9+OmqwY5oKXsHiWc43dei9Y\n" +
-				"yxZ1AAUOIaIdPkq+YG/PhlGE4YcQZ4RPpltAr0HfGgZhmXWigbGS+66pUj+Ojysc\n" +
-				"j0K5tCVxVu0fhhFpOlHv0LWaxCbnkgkQH9jfMEJkAWMOuQINBGCAXCYBEADW6RNr\n" +
-				"ZVGNXvHVBqSiOWaxl1XOiEoiHPt50Aijt25yXbG+0kHIFSoR+1g6Lh20JTCChgfQ\n" +
-				"kGGjzQvEuG1HTw07YhsvLc0pkjNMfu6gJqFox/ogc53mz69OxXauzUQ/TZ27GDVp\n" +
-				"UBu+EhDKt1s3OtA6Bjz/csop/Um7gT0+ivHyvJ/jGdnPEZv8tNuSE/Uo+hn/Q9hg\n" +
-				"8SbveZzo3C+U4KcabCESEFl8Gq6aRi9vAfa65oxD5jKaIz7cy+pwb0lizqlW7H9t\n" +
-				"Qlr3dBfdIcdzgR55hTFC5/XrcwJ6/nHVH/xGskEasnfCQX8RYKMuy0UADJy72TkZ\n" +
-				"bYaCx+XXIcVB8GTOmJVoAhrTSSVLAZspfCnjwnSxisDn3ZzsYrq3cV6sU8b+QlIX\n" +
-				"7VAjurE+5cZiVlaxgCjyhKqlGgmonnReWOBacCgL/UvuwMmMp5TTLmiLXLT7uxeG\n" +
-				"ojEyoCk4sMrqrU1jevHyGlDJH9Taux15GILDwnYFfAvPF9WCid4UZ4Ouwjcaxfys\n" +
-				"3LxNiZIlUsXNKwS3mhiMRL4TRsbs4k4QE+LIMOsauIvcvm8/frydvQ/kUwIhVTH8\n" +
-				"0XGOH909bYtJvY3fudK7ShIwm7ZFTduBJUG473E/Fn3VkhTmBX6+PjOC50HR/Hyb\n" +
-				"waRCzfDruMe3TAcE/tSP5CUOb9C7+P+hPzQcDwARAQABiQRyBBgBCgAmFiEEyHQB\n" +
-				"Hwq0BRENAhBVNDZdlHLXRo8FAmCAXCYCGwIFCQlmAYACQAkQNDZdlHLXRo/BdCAE\n" +
-				"GQEKAB0WIQQ3TsdbSFkTYEqDHMfIIMbVzSerhwUCYIBcJgAKCRDIIMbVzSerh0Xw\n" +
-				"D/9ghnUsoNCu1OulcoJdHboMazJvDt/znttdQSnULBVElgM5zk0Uyv87zFBzuCyQ\n" +
-				"JWL3bWesQ2uFx5fRWEPDEfWVdDrjpQGb1OCCQyz1QlNPV/1M1/xhKGS9EeXrL8Dw\n" +
-				"F6KTGkRwn1yXiP4BGgfeFIQHmJcKXEZ9HkrpNb8mcexkROv4aIPAwn+IaE+NHVtt\n" +
-				"IBnufMXLyfpkWJQtJa9elh9PMLlHHnuvnYLvuAoOkhuvs7fXDMpfFZ01C+QSv1dz\n" +
-				"Hm52GSStERQzZ51w4c0rYDneYDniC/sQT1x3dP5Xf6wzO+EhRMabkvoTbMqPsTEP\n" +
-				"xyWr2pNtTBYp7pfQjsHxhJpQF0xjGN9C39z7f3gJG8IJhnPeulUqEZjhRFyVZQ6/\n" +
-				"siUeq7vu4+dM/JQL+i7KKe7Lp9UMrG6NLMH+ltaoD3+lVm8fdTUxS5MNPoA/I8cK\n" +
-				"1OWTJHkrp7V/XaY7mUtvQn5V1yET5b4bogz4nME6WLiFMd+7x73gB+YJ6MGYNuO8\n" +
-				"e/NFK67MfHbk1/AiPTAJ6s5uHRQIkZcBPG7y5PpfcHpIlwPYCDGYlTajZXblyKrw\n" +
-				"BttVnYKvKsnlysv11glSg0DphGxQJbXzWpvBNyhMNH5dffcfvd3eXJAxnD81GD2z\n" +
-				"ZAriMJ4Av2TfeqQ2nxd2ddn0jX4WVHtAvLXfCgLM2Gveho4jD/9sZ6PZz/rEeTvt\n" +
-				"h88t50qPcBa4bb25X0B5FO3TeK2LL3VKLuEp5lgdcHVonrcdqZFobN1CgGJua8TW\n" +
-				"SprIkh+8ATZ/FXQTi01NzLhHXT1IQzSpFaZw0gb2f5ruXwvTPpfXzQrs2omY+7s7\n" +
-				"fkCwGPesvpSXPKn9v8uhUwD7NGW/Dm+jUM+QtC/FqzX7+/Q+OuEPjClUh1cqopCZ\n" +
-				"EvAI3HjnavGrYuU6DgQdjyGT/UDbuwbCXqHxHojVVkISGzCTGpmBcQYQqhcFRedJ\n" +
-				"yJlu6PSXlA7+8Ajh52oiMJ3ez4xSssFgUQAyOB16432tm4erpGmCyakkoRmMUn3p\n" +
-				"wx+QIppxRlsHznhcCQKR3tcblUqH3vq5i4/ZAihusMCa0YrShtxfdSb13oKX+pFr\n" +
-				"aZXvxyZlCa5qoQQBV1sowmPL1N2j3dR9TVpdTyCFQSv4KeiExmowtLIjeCppRBEK\n" +
-				"eeYHJnlfkyKXPhxTVVO6H+dU4nVu0ASQZ07KiQjbI+zTpPKFLPp3/0sPRJM57r1+\n" +
-				"aTS71iR7nZNZ1f8LZV2OvGE6fJVtgJ1J4Nu02K54uuIhU3tg1+7Xt+IqwRc9rbVr\n" +
-				"pHH/hFCYBPW2D2dxB+k2pQlg5NI+TpsXj5Zun8kRw5RtVb+dLuiH/xmxArIee8Jq\n" +
-				"ZF5q4h4I33PSGDdSvGXn9UMY5Isjpg==\n" +
-				"=7pIB\n" +
-				"-----END PGP PUBLIC KEY BLOCK-----\n",
-			FileExtensions: []string{"tf"},
+			ID:                     "terraform",
+			Name:                   "Terraform",
+			DefaultMirror:          "https://releases.hashicorp.com/terraform/index.json",
+			DefaultDownloadMirror:  "https://releases.hashicorp.com/terraform",
+			VersionPrefix:          "terraform_",
+			ExecutableName:         "terraform",
+			ArchivePrefix:          "terraform_",
+			PublicKeyId:            hashicorpPublicKeyId,
+			PublicKeyURLs:          hashicorpPublicKeyURLs,
+			PublicKeyLegacyLiteral: hashicorpPublicKeyLegacyLiteral,
+			FileExtensions:         []string{"tf"},
+			VersionFileNames:       []string{".terraform-version"},
+			PlatformRules: append([]PlatformRule{
+				{OS: "darwin", Arch: "arm64", Since: "1.0.2", FallbackArch: "amd64"},
+				{OS: "freebsd", Arch: "arm64", Unavailable: true},
+			}, hashicorpPlatformRules...),
 		},
 	},
 	OpenTofuProduct{
@@ -426,6 +597,40 @@ var products = []Product{
 			PublicKeyURLs:          []string{"https://get.opentofu.org/opentofu.asc"},
 			PublicKeyLegacyLiteral: "",
 			FileExtensions:         []string{"tf", "tofu"},
+			ReleaseTagPrefix:       "v",
+			VersionFileNames:       []string{".opentofu-version"},
+			// Built for darwin/arm64 from the first release
+			PlatformRules: []PlatformRule{
+				{OS: "linux", Arch: "riscv64", Unavailable: true},
+			},
+		},
+	},
+	newHashiCorpProduct("packer", "Packer"),
+	newHashiCorpProduct("vault", "Vault"),
+	newHashiCorpProduct("consul", "Consul"),
+	newHashiCorpProduct("nomad", "Nomad"),
+	newHashiCorpProduct("boundary", "Boundary"),
+	TerragruntProduct{
+		ProductDetails{
+			ID:                    "terragrunt",
+			Name:                  "Terragrunt",
+			DefaultMirror:         "https://api.github.com/repos/gruntwork-io/terragrunt/releases",
+			DefaultDownloadMirror: "https://github.com/gruntwork-io/terragrunt/releases/download",
+			VersionPrefix:         "terragrunt_",
+			ExecutableName:        "terragrunt",
+			ArchivePrefix:         "terragrunt_",
+			ArchiveFormat:         ArchiveFormatBinary,
+			VersionsSource:        VersionsSourceGitHubReleases,
+			ReleaseTagPrefix:      "v",
+			VersionFileNames:      []string{".terragrunt-version"},
+			UnsignedReleases:      true,
+			PlatformRules: []PlatformRule{
+				{OS: "darwin", Arch: "arm64", Since: "0.28.12", FallbackArch: "amd64"},
+				{OS: "freebsd", Unavailable: true},
+				{OS: "openbsd", Unavailable: true},
+				{OS: "solaris", Unavailable: true},
+				{OS: "linux", Arch: "riscv64", Unavailable: true},
+			},
 		},
 	},
 }
@@ -444,6 +649,31 @@ func GetAllProducts() []Product {
 	return products
 }
 
+// RegisterProduct : make product available next to the built-in ones.
+// A previously registered custom product with the same ID is replaced,
+// while built-in products cannot be redefined.
+func RegisterProduct(product CustomProduct) error {
+	if product.GetId() == "" {
+		return errors.New("product ID must not be empty")
+	}
+
+	for idx, existing := range products {
+		if !strings.EqualFold(existing.GetId(), product.GetId()) {
+			continue
+		}
+		if _, isCustom := existing.(CustomProduct); !isCustom {
+			return fmt.Errorf("product %q is built-in and cannot be redefined", existing.GetId())
+		}
+		logger.Debugf("Replacing previously registered %q product", existing.GetId())
+		products[idx] = product
+		return nil
+	}
+
+	logger.Debugf("Registering %q product", product.GetId())
+	products = append(products, product)
+	return nil
+}
+
 // Obtain produced used by deprecated public methods that
 // now expect a product to be called.
 // Once these public methods are removed, this function can be removed
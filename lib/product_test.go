@@ -149,8 +149,8 @@ func Test_GetShaSignatureSuffix_Terraform(t *testing.T) {
 
 func Test_GetRecentVersionProduct_Terraform(t *testing.T) {
 	recentFile := RecentFile{
-		OpenTofu:  []string{"1.2.3", "3.2.1"},
-		Terraform: []string{"5.4.3", "3.4.5"},
+		"opentofu":  []string{"1.2.3", "3.2.1"},
+		"terraform": []string{"5.4.3", "3.4.5"},
 	}
 	expected := []string{"5.4.3", "3.4.5"}
 
@@ -164,19 +164,19 @@ func Test_GetRecentVersionProduct_Terraform(t *testing.T) {
 
 func Test_SetRecentVersionProduct_Terraform(t *testing.T) {
 	recentFile := RecentFile{
-		OpenTofu:  []string{"1.2.3", "3.2.1"},
-		Terraform: []string{"5.4.3", "3.4.5"},
+		"opentofu":  []string{"1.2.3", "3.2.1"},
+		"terraform": []string{"5.4.3", "3.4.5"},
 	}
 	expected := []string{"1.0.0", "1.0.1"}
 
 	product := GetProductById("terraform")
 	product.SetRecentVersionProduct(&recentFile, expected)
-	err := compareLists(recentFile.Terraform, expected)
+	err := compareLists(recentFile["terraform"], expected)
 	if err != nil {
 		t.Error(err)
 	}
 
-	err = compareLists(recentFile.OpenTofu, expected)
+	err = compareLists(recentFile["opentofu"], expected)
 	if err == nil {
 		t.Error("OpenTofu version list should not match version set for Terraform")
 	}
@@ -287,37 +287,144 @@ func Test_GetShaSignatureSuffix_OpenTofu(t *testing.T) {
 	}
 }
 
-func Test_GetRecentVersionProduct_OpenTofu(t *testing.T) {
-	recentFile := RecentFile{
-		Terraform: []string{"1.2.3", "3.2.1"},
-		OpenTofu:  []string{"5.4.3", "3.4.5"},
+// HashiCorp product tests
+func Test_HashiCorpProducts(t *testing.T) {
+	terraform := GetProductById("terraform")
+	for _, id := range []string{"packer", "vault", "consul", "nomad", "boundary"} {
+		product := GetProductById(id)
+		if product == nil {
+			t.Errorf("%s product returned nil", id)
+			continue
+		}
+		if _, ok := product.(HashiCorpProduct); !ok {
+			t.Errorf("%s product is not a HashiCorpProduct: %T", id, product)
+		}
+		if expected := "https://releases.hashicorp.com/" + id + "/index.json"; product.GetDefaultMirrorUrl() != expected {
+			t.Errorf("Unexpected %s default mirror. Expected: %q, actual: %q", id, expected, product.GetDefaultMirrorUrl())
+		}
+		if expected := "https://releases.hashicorp.com/" + id + "/1.2.3"; product.GetArtifactUrl("", "1.2.3") != expected {
+			t.Errorf("Unexpected %s artifact URL. Expected: %q, actual: %q", id, expected, product.GetArtifactUrl("", "1.2.3"))
+		}
+		if expected := "https://example.com/mirror/1.2.3"; product.GetArtifactUrl("https://example.com/mirror/", "1.2.3") != expected {
+			t.Errorf("Unexpected %s artifact URL on custom mirror. Expected: %q, actual: %q", id, expected, product.GetArtifactUrl("https://example.com/mirror/", "1.2.3"))
+		}
+		if product.GetExecutableName() != id || product.GetVersionPrefix() != id+"_" || product.GetArchivePrefix() != id+"_" {
+			t.Errorf("Unexpected %s naming: executable %q, version prefix %q, archive prefix %q",
+				id, product.GetExecutableName(), product.GetVersionPrefix(), product.GetArchivePrefix())
+		}
+		if product.GetShaSignatureSuffix() != terraform.GetShaSignatureSuffix() ||
+			!slices.Equal(product.GetPublicKeyURLs(), terraform.GetPublicKeyURLs()) ||
+			product.GetPublicKeyLegacyLiteral() != terraform.GetPublicKeyLegacyLiteral() {
+			t.Errorf("%s product is not signed with the HashiCorp key", id)
+		}
 	}
-	expected := []string{"5.4.3", "3.4.5"}
+}
 
-	product := GetProductById("opentofu")
-	actual := product.GetRecentVersionProduct(&recentFile)
-	err := compareLists(actual, expected)
+func Test_GetVersionFileNames(t *testing.T) {
+	for id, expected := range map[string][]string{
+		"terraform":  {".terraform-version"},
+		"opentofu":   {".opentofu-version"},
+		"terragrunt": {".terragrunt-version"},
+		"packer":     {".packer-version"},
+	} {
+		if actual := GetProductById(id).GetVersionFileNames(); !slices.Equal(actual, expected) {
+			t.Errorf("Unexpected %s version file names. Expected: %q, actual: %q", id, expected, actual)
+		}
+	}
+}
+
+func Test_GetVersionsFromJSON_HashiCorp(t *testing.T) {
+	product := GetProductById("packer")
+	versions, err := product.GetVersionsFromJSON([]byte(`{"name":"packer","versions":{"1.11.0":{},"1.10.3":{}}}`))
 	if err != nil {
-		t.Error(err)
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	slices.Sort(versions)
+	if expected := []string{"1.10.3", "1.11.0"}; !slices.Equal(versions, expected) {
+		t.Errorf("Unexpected versions. Expected: %q, actual: %q", expected, versions)
 	}
 }
 
-func Test_SetRecentVersionProduct_OpenTofu(t *testing.T) {
-	recentFile := RecentFile{
-		Terraform: []string{"1.2.3", "3.2.1"},
-		OpenTofu:  []string{"5.4.3", "3.4.5"},
+// Terragrunt Tests
+func Test_Terragrunt(t *testing.T) {
+	product := GetProductById("terragrunt")
+	if product == nil {
+		t.Fatal("Terragrunt product returned nil")
+	}
+	if actual := product.GetArchiveFormat(); actual != ArchiveFormatBinary {
+		t.Errorf("Unexpected archive format. Expected: %q, actual: %q", ArchiveFormatBinary, actual)
+	}
+	if expected, actual := "https://github.com/gruntwork-io/terragrunt/releases/download/v0.67.0", product.GetArtifactUrl("", "0.67.0"); actual != expected {
+		t.Errorf("Unexpected artifact URL. Expected: %q, actual: %q", expected, actual)
+	}
+	if expected, actual := "terragrunt_linux_amd64", product.GetArtifactFileName(product.GetArchivePrefix(), "0.67.0", "linux", "amd64"); actual != expected {
+		t.Errorf("Unexpected artifact file name. Expected: %q, actual: %q", expected, actual)
+	}
+	if expected, actual := "terragrunt_windows_amd64.exe", product.GetArtifactFileName(product.GetArchivePrefix(), "0.67.0", "windows", "amd64"); actual != expected {
+		t.Errorf("Unexpected artifact file name. Expected: %q, actual: %q", expected, actual)
+	}
+	if expected, actual := "SHA256SUMS", product.GetChecksumsFileName(product.GetArchivePrefix(), "0.67.0"); actual != expected {
+		t.Errorf("Unexpected checksums file name. Expected: %q, actual: %q", expected, actual)
+	}
+	if len(product.GetPublicKeyURLs()) != 0 {
+		t.Errorf("Terragrunt checksums are not signed, got public key URLs: %q", product.GetPublicKeyURLs())
 	}
-	expected := []string{"1.0.0", "1.0.1"}
 
-	product := GetProductById("opentofu")
-	product.SetRecentVersionProduct(&recentFile, expected)
-	err := compareLists(recentFile.OpenTofu, expected)
+	versions, err := product.GetVersionsFromJSON([]byte(`[{"tag_name":"v0.67.1","assets":[{"name":"SHA256SUMS"}]},{"tag_name":"v0.68.0","draft":true},{"tag_name":"v0.67.0-beta1","prerelease":true,"assets":[{"name":"SHA256SUMS"}]}]`))
 	if err != nil {
-		t.Error(err)
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"0.67.1", "0.67.0-beta1"}; !slices.Equal(versions, expected) {
+		t.Errorf("Unexpected versions. Expected: %q, actual: %q", expected, versions)
 	}
+}
 
-	err = compareLists(recentFile.Terraform, expected)
-	if err == nil {
-		t.Error("OpenTofu version list should not match version set for Terraform")
+func Test_GetArtifactFileName_Terraform(t *testing.T) {
+	product := GetProductById("terraform")
+	if expected, actual := "terraform_1.10.4_linux_amd64.zip", product.GetArtifactFileName(product.GetArchivePrefix(), "1.10.4", "linux", "amd64"); actual != expected {
+		t.Errorf("Unexpected artifact file name. Expected: %q, actual: %q", expected, actual)
+	}
+	if expected, actual := "terraform_1.10.4_SHA256SUMS", product.GetChecksumsFileName(product.GetArchivePrefix(), "1.10.4"); actual != expected {
+		t.Errorf("Unexpected checksums file name. Expected: %q, actual: %q", expected, actual)
+	}
+	if actual := product.GetArchiveFormat(); actual != ArchiveFormatZip {
+		t.Errorf("Unexpected archive format. Expected: %q, actual: %q", ArchiveFormatZip, actual)
+	}
+}
+
+func Test_RegisterProduct(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	productsCount := len(GetAllProducts())
+	custom := CustomProduct{
+		ProductDetails: ProductDetails{
+			ID:             "registertest",
+			Name:           "Register Test",
+			ExecutableName: "registertest",
+		},
+	}
+	if err := RegisterProduct(custom); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if GetProductById("RegisterTest") == nil {
+		t.Error("Registered product could not be found")
+	}
+
+	custom.Name = "Register Test Replaced"
+	if err := RegisterProduct(custom); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := productsCount + 1; len(GetAllProducts()) != expected {
+		t.Errorf("Unexpected number of products. Expected: %d, actual: %d", expected, len(GetAllProducts()))
+	}
+	if actual := GetProductById("registertest").GetName(); actual != custom.Name {
+		t.Errorf("Registered product was not replaced. Expected: %q, actual: %q", custom.Name, actual)
+	}
+
+	// Built-in products cannot be redefined
+	if err := RegisterProduct(CustomProduct{ProductDetails: ProductDetails{ID: "Terraform"}}); err == nil {
+		t.Error("Expected error when redefining built-in product. Got nil")
+	}
+	if err := RegisterProduct(CustomProduct{}); err == nil {
+		t.Error("Expected error when registering product without ID. Got nil")
 	}
 }
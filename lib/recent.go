@@ -4,13 +4,12 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
-type RecentFile struct {
-	Terraform []string `json:"terraform"`
-	OpenTofu  []string `json:"opentofu"`
-}
+// RecentFile : recently used versions, most recent first, keyed by product ID
+type RecentFile map[string][]string
 
 func addRecent(requestedVersion string, installPath string, product Product) {
 	if !validVersionFormat(requestedVersion) {
@@ -19,14 +18,14 @@ func addRecent(requestedVersion string, installPath string, product Product) {
 	}
 	installLocation := GetInstallLocation(installPath)
 	recentFilePath := filepath.Join(installLocation, recentFile)
-	var recentFileData RecentFile
+	recentFileData := RecentFile{}
 	unmarshalRecentFileData(recentFilePath, &recentFileData)
 	prependRecentVersionToList(requestedVersion, product, &recentFileData)
 	saveRecentFile(recentFileData, recentFilePath)
 }
 
 func prependRecentVersionToList(version string, product Product, r *RecentFile) {
-	sliceToCheck := product.GetRecentVersionProduct(r)
+	sliceToCheck := (*r)[product.GetId()]
 	for versionIndex, versionValue := range sliceToCheck {
 		if versionValue == version {
 			sliceToCheck = append(sliceToCheck[:versionIndex], sliceToCheck[versionIndex+1:]...)
@@ -34,15 +33,15 @@ func prependRecentVersionToList(version string, product Product, r *RecentFile)
 	}
 	sliceToCheck = append([]string{version}, sliceToCheck...)
 
-	product.SetRecentVersionProduct(r, sliceToCheck)
+	(*r)[product.GetId()] = sliceToCheck
 }
 
 func getRecentVersions(installPath string, product Product) ([]string, error) {
 	installLocation := GetInstallLocation(installPath)
 	recentFilePath := filepath.Join(installLocation, recentFile)
-	var recentFileData RecentFile
+	recentFileData := RecentFile{}
 	unmarshalRecentFileData(recentFilePath, &recentFileData)
-	listOfRecentVersions := product.GetRecentVersionProduct(&recentFileData)
+	listOfRecentVersions := recentFileData[product.GetId()]
 	var returnedRecentVersions []string
 	for i := range min(len(listOfRecentVersions), 5) {
 		returnedRecentVersions = append(returnedRecentVersions, listOfRecentVersions[i])
@@ -51,6 +50,9 @@ func getRecentVersions(installPath string, product Product) ([]string, error) {
 }
 
 func unmarshalRecentFileData(recentFilePath string, recentFileData *RecentFile) {
+	if *recentFileData == nil {
+		*recentFileData = RecentFile{}
+	}
 	if !CheckFileExist(recentFilePath) {
 		return
 	}
@@ -62,18 +64,48 @@ func unmarshalRecentFileData(recentFilePath string, recentFileData *RecentFile)
 	if len(string(recentFileContent)) >= 1 && string(recentFileContent[0:1]) != "{" {
 		convertOldRecentFile(recentFileContent, recentFileData)
 	} else {
-		err = json.Unmarshal(recentFileContent, &recentFileData)
+		var rawRecentFileData map[string]json.RawMessage
+		err = json.Unmarshal(recentFileContent, &rawRecentFileData)
 		if err != nil {
 			logger.Errorf("Could not unmarshal recent versions content from %q file", recentFilePath)
+			return
 		}
+		convertRecentFileJSON(rawRecentFileData, recentFileData)
 	}
 }
 
+// convertOldRecentFile : migrate legacy format (one Terraform version per line)
 func convertOldRecentFile(content []byte, recentFileData *RecentFile) {
 	lines := strings.SplitSeq(string(content), "\n")
 	for s := range lines {
 		if s != "" {
-			recentFileData.Terraform = append(recentFileData.Terraform, s)
+			(*recentFileData)[legacyProductId] = append((*recentFileData)[legacyProductId], s)
+		}
+	}
+}
+
+// convertRecentFileJSON : migrate JSON format with fixed set of products (e.g. `{"terraform": [...], "openTofu": null}`).
+// Product IDs are normalized, while empty lists and invalid versions are dropped.
+func convertRecentFileJSON(rawRecentFileData map[string]json.RawMessage, recentFileData *RecentFile) {
+	for key, rawVersions := range rawRecentFileData {
+		var versions []string
+		if err := json.Unmarshal(rawVersions, &versions); err != nil {
+			logger.Warnf("Skipping unexpected recent versions of %q: %s", key, rawVersions)
+			continue
+		}
+
+		productID := strings.ToLower(key)
+		if product := GetProductById(key); product != nil {
+			productID = product.GetId()
+		}
+		for _, version := range versions {
+			if !validVersionFormat(version) {
+				logger.Warnf("Skipping invalid recent version of %q: %q", key, version)
+				continue
+			}
+			if !slices.Contains((*recentFileData)[productID], version) {
+				(*recentFileData)[productID] = append((*recentFileData)[productID], version)
+			}
 		}
 	}
 }
@@ -11,20 +11,20 @@ import (
 func Test_convertData(t *testing.T) {
 	recentFileContent := []byte("1.5.6\n0.13.0-rc1\n1.0.11\n")
 
-	var recentFileData RecentFile
+	recentFileData := RecentFile{}
 	convertOldRecentFile(recentFileContent, &recentFileData)
-	assert.Equal(t, 3, len(recentFileData.Terraform))
-	assert.Equal(t, 0, len(recentFileData.OpenTofu))
-	assert.Equal(t, "1.5.6", recentFileData.Terraform[0])
-	assert.Equal(t, "0.13.0-rc1", recentFileData.Terraform[1])
-	assert.Equal(t, "1.0.11", recentFileData.Terraform[2])
+	assert.Equal(t, 3, len(recentFileData["terraform"]))
+	assert.Equal(t, 0, len(recentFileData["opentofu"]))
+	assert.Equal(t, "1.5.6", recentFileData["terraform"][0])
+	assert.Equal(t, "0.13.0-rc1", recentFileData["terraform"][1])
+	assert.Equal(t, "1.0.11", recentFileData["terraform"][2])
 
 	// Test with empty data
 	recentFileContent = []byte("")
 	recentFileData = RecentFile{}
 	convertOldRecentFile(recentFileContent, &recentFileData)
-	assert.Equal(t, 0, len(recentFileData.Terraform))
-	assert.Equal(t, 0, len(recentFileData.OpenTofu))
+	assert.Equal(t, 0, len(recentFileData["terraform"]))
+	assert.Equal(t, 0, len(recentFileData["opentofu"]))
 }
 
 // Test_unmarshalRecentFileData_conversion : Test unmarshalRecentFileData with old version format
@@ -37,19 +37,19 @@ func Test_unmarshalRecentFileData_conversion(t *testing.T) {
 
 	t.Log("Test one version")
 	expectedRecentFileData = RecentFile{
-		Terraform: []string{"1.3.2"},
+		"terraform": []string{"1.3.2"},
 	}
 	performUnmarshalRecentFileDataTest(t, "1.3.2", &expectedRecentFileData)
 
 	t.Log("Test trailing new line")
 	expectedRecentFileData = RecentFile{
-		Terraform: []string{"1.3.2"},
+		"terraform": []string{"1.3.2"},
 	}
 	performUnmarshalRecentFileDataTest(t, "1.3.2\n", &expectedRecentFileData)
 
 	t.Log("Test multiple versions")
 	expectedRecentFileData = RecentFile{
-		Terraform: []string{"1.3.2", "1.2.3"},
+		"terraform": []string{"1.3.2", "1.2.3"},
 	}
 	performUnmarshalRecentFileDataTest(t, "1.3.2\n1.2.3\n", &expectedRecentFileData)
 }
@@ -68,22 +68,38 @@ func Test_unmarshalRecentFileData(t *testing.T) {
 
 	t.Log("Test only Terraform")
 	expectedRecentFileData = RecentFile{
-		Terraform: []string{"1.5.0", "1.6.0"},
+		"terraform": []string{"1.5.0", "1.6.0"},
 	}
 	performUnmarshalRecentFileDataTest(t, `{"terraform": ["1.5.0", "1.6.0"]}`, &expectedRecentFileData)
 
 	t.Log("Test only OpenTofu")
 	expectedRecentFileData = RecentFile{
-		OpenTofu: []string{"1.5.0", "1.6.0"},
+		"opentofu": []string{"1.5.0", "1.6.0"},
 	}
 	performUnmarshalRecentFileDataTest(t, `{"opentofu": ["1.5.0", "1.6.0"]}`, &expectedRecentFileData)
 
 	t.Log("Test both")
 	expectedRecentFileData = RecentFile{
-		Terraform: []string{"1.2.3", "1.3.2"},
-		OpenTofu:  []string{"1.5.0", "1.6.0"},
+		"terraform": []string{"1.2.3", "1.3.2"},
+		"opentofu":  []string{"1.5.0", "1.6.0"},
 	}
 	performUnmarshalRecentFileDataTest(t, `{"terraform": ["1.2.3", "1.3.2"], "opentofu": ["1.5.0", "1.6.0"]}`, &expectedRecentFileData)
+
+	t.Log("Test other products")
+	expectedRecentFileData = RecentFile{
+		"packer":     []string{"1.11.2"},
+		"my-product": []string{"0.1.0"},
+	}
+	performUnmarshalRecentFileDataTest(t, `{"packer": ["1.11.2"], "my-product": ["0.1.0"]}`, &expectedRecentFileData)
+}
+
+// Test_unmarshalRecentFileData_migration : Test unmarshalRecentFileData with fixed set of products format
+func Test_unmarshalRecentFileData_migration(t *testing.T) {
+	expectedRecentFileData := RecentFile{
+		"terraform": []string{"1.2.3", "4.5.6"},
+		"opentofu":  []string{"6.6.6"},
+	}
+	performUnmarshalRecentFileDataTest(t, `{"terraform": ["1.2.3", "4.5.6"], "openTofu": ["6.6.6"], "packer": null}`, &expectedRecentFileData)
 }
 
 func performUnmarshalRecentFileDataTest(t *testing.T, recentFileContent string, expectedRecentFileData *RecentFile) {
@@ -100,16 +116,13 @@ func performUnmarshalRecentFileDataTest(t *testing.T, recentFileContent string,
 
 	recentFileData := RecentFile{}
 	unmarshalRecentFileData(pathToTempFile, &recentFileData)
-	t.Log("Comparing Terraform versions")
-	assert.Equal(t, expectedRecentFileData.Terraform, recentFileData.Terraform)
-	t.Log("Comparing OpenTofu versions")
-	assert.Equal(t, expectedRecentFileData.OpenTofu, recentFileData.OpenTofu)
+	assert.Equal(t, *expectedRecentFileData, recentFileData)
 }
 
 func Test_saveFile(t *testing.T) {
 	recentFileData := RecentFile{
-		Terraform: []string{"1.2.3", "4.5.6"},
-		OpenTofu:  []string{"6.6.6"},
+		"terraform": []string{"1.2.3", "4.5.6"},
+		"opentofu":  []string{"6.6.6"},
 	}
 	temp, err := os.MkdirTemp("", "recent-test")
 	if err != nil {
@@ -125,7 +138,7 @@ func Test_saveFile(t *testing.T) {
 	if err != nil {
 		t.Errorf("Could not read converted file %v", pathToTempFile)
 	}
-	assert.Equal(t, "{\"terraform\":[\"1.2.3\",\"4.5.6\"],\"opentofu\":[\"6.6.6\"]}", string(content))
+	assert.Equal(t, "{\"opentofu\":[\"6.6.6\"],\"terraform\":[\"1.2.3\",\"4.5.6\"]}", string(content))
 }
 
 func Test_getRecentVersionsForTerraform(t *testing.T) {
@@ -153,6 +166,7 @@ func Test_addRecent(t *testing.T) {
 	logger = InitLogger("DEBUG")
 	terraform := GetProductById("terraform")
 	opentofu := GetProductById("opentofu")
+	packer := GetProductById("packer")
 	temp, err := os.MkdirTemp("", "recent-test")
 	defer func(path string) {
 		_ = os.RemoveAll(path)
@@ -169,14 +183,14 @@ func Test_addRecent(t *testing.T) {
 		t.Errorf("Could not open file %v", filePath)
 		t.Error(err)
 	}
-	assert.Equal(t, "{\"terraform\":[\"3.7.2\",\"3.7.1\",\"3.7.0\"],\"opentofu\":null}", string(bytes))
+	assert.Equal(t, "{\"terraform\":[\"3.7.2\",\"3.7.1\",\"3.7.0\"]}", string(bytes))
 	addRecent("3.7.0", temp, terraform)
 	bytes, err = os.ReadFile(filePath)
 	if err != nil {
 		t.Errorf("Could not open file %v", filePath)
 		t.Error(err)
 	}
-	assert.Equal(t, "{\"terraform\":[\"3.7.0\",\"3.7.2\",\"3.7.1\"],\"opentofu\":null}", string(bytes))
+	assert.Equal(t, "{\"terraform\":[\"3.7.0\",\"3.7.2\",\"3.7.1\"]}", string(bytes))
 
 	addRecent("1.1.1", temp, opentofu)
 	bytes, err = os.ReadFile(filePath)
@@ -184,37 +198,45 @@ func Test_addRecent(t *testing.T) {
 		t.Error("Could not open file")
 		t.Error(err)
 	}
-	assert.Equal(t, "{\"terraform\":[\"3.7.0\",\"3.7.2\",\"3.7.1\"],\"opentofu\":[\"1.1.1\"]}", string(bytes))
+	assert.Equal(t, "{\"opentofu\":[\"1.1.1\"],\"terraform\":[\"3.7.0\",\"3.7.2\",\"3.7.1\"]}", string(bytes))
+
+	addRecent("1.11.4", temp, packer)
+	bytes, err = os.ReadFile(filePath)
+	if err != nil {
+		t.Error("Could not open file")
+		t.Error(err)
+	}
+	assert.Equal(t, "{\"opentofu\":[\"1.1.1\"],\"packer\":[\"1.11.4\"],\"terraform\":[\"3.7.0\",\"3.7.2\",\"3.7.1\"]}", string(bytes))
 }
 
 func Test_prependExistingVersionIsMovingToTop(t *testing.T) {
 	product := GetProductById("terraform")
 	recentFileData := RecentFile{
-		Terraform: []string{"1.2.3", "4.5.6", "7.7.7"},
-		OpenTofu:  []string{"6.6.6"},
+		"terraform": []string{"1.2.3", "4.5.6", "7.7.7"},
+		"opentofu":  []string{"6.6.6"},
 	}
 	prependRecentVersionToList("7.7.7", product, &recentFileData)
-	assert.Equal(t, 3, len(recentFileData.Terraform))
-	assert.Equal(t, "7.7.7", recentFileData.Terraform[0])
-	assert.Equal(t, "1.2.3", recentFileData.Terraform[1])
-	assert.Equal(t, "4.5.6", recentFileData.Terraform[2])
+	assert.Equal(t, 3, len(recentFileData["terraform"]))
+	assert.Equal(t, "7.7.7", recentFileData["terraform"][0])
+	assert.Equal(t, "1.2.3", recentFileData["terraform"][1])
+	assert.Equal(t, "4.5.6", recentFileData["terraform"][2])
 
 	prependRecentVersionToList("1.2.3", product, &recentFileData)
-	assert.Equal(t, 3, len(recentFileData.Terraform))
-	assert.Equal(t, "1.2.3", recentFileData.Terraform[0])
-	assert.Equal(t, "7.7.7", recentFileData.Terraform[1])
-	assert.Equal(t, "4.5.6", recentFileData.Terraform[2])
+	assert.Equal(t, 3, len(recentFileData["terraform"]))
+	assert.Equal(t, "1.2.3", recentFileData["terraform"][0])
+	assert.Equal(t, "7.7.7", recentFileData["terraform"][1])
+	assert.Equal(t, "4.5.6", recentFileData["terraform"][2])
 }
 
 func Test_prependNewVersion(t *testing.T) {
 	product := GetProductById("terraform")
 	recentFileData := RecentFile{
-		Terraform: []string{"1.2.3", "4.5.6", "4.5.7", "4.5.8", "4.5.9"},
-		OpenTofu:  []string{"6.6.6"},
+		"terraform": []string{"1.2.3", "4.5.6", "4.5.7", "4.5.8", "4.5.9"},
+		"opentofu":  []string{"6.6.6"},
 	}
 	prependRecentVersionToList("7.7.7", product, &recentFileData)
-	assert.Equal(t, 6, len(recentFileData.Terraform))
-	assert.Equal(t, "7.7.7", recentFileData.Terraform[0])
-	assert.Equal(t, "1.2.3", recentFileData.Terraform[1])
-	assert.Equal(t, "4.5.6", recentFileData.Terraform[2])
+	assert.Equal(t, 6, len(recentFileData["terraform"]))
+	assert.Equal(t, "7.7.7", recentFileData["terraform"][0])
+	assert.Equal(t, "1.2.3", recentFileData["terraform"][1])
+	assert.Equal(t, "4.5.6", recentFileData["terraform"][2])
 }
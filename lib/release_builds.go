@@ -0,0 +1,146 @@
+//nolint:staticcheck //ST1005: error strings should not be capitalized (staticcheck)
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Struct representing release metadata of HashiCorp releases JSON:
+// https://releases.hashicorp.com/terraform/index.json
+type HashiCorpReleasesJSON struct {
+	Versions map[string]HashiCorpRelease `json:"versions"`
+}
+
+type HashiCorpRelease struct {
+	Version           string           `json:"version"`
+	Shasums           string           `json:"shasums"`
+	ShasumsSignature  string           `json:"shasums_signature"`
+	ShasumsSignatures []string         `json:"shasums_signatures"`
+	Builds            []HashiCorpBuild `json:"builds"`
+}
+
+type HashiCorpBuild struct {
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+// ReleaseArtifact : location of the release artifact along with its checksums and their signature
+type ReleaseArtifact struct {
+	URL                   string
+	ChecksumsURL          string
+	ChecksumsSignatureURL string
+}
+
+// GetBuild : get build of the release for the platform
+func (r HashiCorpRelease) GetBuild(goos, goarch string) (HashiCorpBuild, bool) {
+	for _, build := range r.Builds {
+		if build.OS == goos && build.Arch == goarch {
+			return build, true
+		}
+	}
+	return HashiCorpBuild{}, false
+}
+
+// GetPlatforms : get sorted list of platforms (e.g. "linux_amd64") the release is built for
+func (r HashiCorpRelease) GetPlatforms() []string {
+	platforms := make([]string, 0, len(r.Builds))
+	for _, build := range r.Builds {
+		platforms = append(platforms, build.OS+"_"+build.Arch)
+	}
+	slices.Sort(platforms)
+	return slices.Compact(platforms)
+}
+
+// getHashiCorpRelease : get release metadata of the version from HashiCorp releases JSON
+func getHashiCorpRelease(body []byte, version string) (HashiCorpRelease, bool, error) {
+	var releases HashiCorpReleasesJSON
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return HashiCorpRelease{}, false, err
+	}
+	release, found := releases.Versions[version]
+	return release, found, nil
+}
+
+// hasHashiCorpReleasesJSON : whether the product mirror serves HashiCorp releases JSON, which lists builds metadata
+func hasHashiCorpReleasesJSON(product Product) bool {
+	switch p := product.(type) {
+	case TerraformProduct, HashiCorpProduct:
+		return true
+	case CustomProduct:
+		return p.VersionsFormat == VersionsFormatTerraform || p.VersionsFormat == ""
+	default:
+		return false
+	}
+}
+
+// GetReleaseArtifact : get location of the release artifact for the platform from the builds metadata
+// of the versions list at mirrorURL. Returns nil if the mirror doesn't publish builds metadata.
+// Returns error listing available platforms if the version is not built for the platform.
+func GetReleaseArtifact(product Product, mirrorURL, mirrorDownloadURL, version, goos, goarch string) (*ReleaseArtifact, error) {
+	if product.GetVersionsSource() != VersionsSourceJSON || !hasHashiCorpReleasesJSON(product) {
+		return nil, nil
+	}
+
+	body, err := getTFURLBody(mirrorURL)
+	if err != nil {
+		return nil, err
+	}
+	release, found, err := getHashiCorpRelease([]byte(body), version)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse %s releases from %q: %v", product.GetName(), mirrorURL, err)
+	}
+	if !found || len(release.Builds) == 0 {
+		logger.Debugf("No builds metadata for %s version %q at %q", product.GetName(), version, mirrorURL)
+		return nil, nil
+	}
+
+	logger.Debugf("%s version %q is available for: %s", product.GetName(), version, strings.Join(release.GetPlatforms(), ", "))
+	build, found := release.GetBuild(goos, goarch)
+	if !found {
+		return nil, fmt.Errorf("%s version %q is not available for %q. Available platforms: %s",
+			product.GetName(), version, goos+"_"+goarch, strings.Join(release.GetPlatforms(), ", "))
+	}
+
+	releaseURL := product.GetArtifactUrl(mirrorDownloadURL, version)
+	artifact := &ReleaseArtifact{
+		URL:          releaseURL + "/" + build.Filename,
+		ChecksumsURL: releaseURL + "/" + product.GetChecksumsFileName(product.GetArchivePrefix(), version),
+	}
+	// Published URL is only relevant when downloading from the default location,
+	// otherwise the artifact is expected to be found next to the checksums
+	if build.URL != "" && isDefaultDownloadMirror(product, mirrorDownloadURL) {
+		artifact.URL = build.URL
+	}
+	if release.Shasums != "" {
+		artifact.ChecksumsURL = releaseURL + "/" + release.Shasums
+	}
+	artifact.ChecksumsSignatureURL = artifact.ChecksumsURL + "." + product.GetShaSignatureSuffix()
+	if signature := getShasumsSignature(product, release); signature != "" {
+		artifact.ChecksumsSignatureURL = releaseURL + "/" + signature
+	}
+
+	logger.Debugf("Using %s artifact from builds metadata: %q", product.GetName(), artifact.URL)
+	return artifact, nil
+}
+
+// getShasumsSignature : get file name of the checksums signature made with the product key,
+// falling back to the generic signature for releases predating key-specific signatures
+func getShasumsSignature(product Product, release HashiCorpRelease) string {
+	for _, signature := range release.ShasumsSignatures {
+		if strings.HasSuffix(signature, "."+product.GetShaSignatureSuffix()) {
+			return signature
+		}
+	}
+	return release.ShasumsSignature
+}
+
+// isDefaultDownloadMirror : whether the download mirror is not overridden
+func isDefaultDownloadMirror(product Product, mirrorDownloadURL string) bool {
+	return mirrorDownloadURL == "" ||
+		strings.TrimSuffix(mirrorDownloadURL, "/") == strings.TrimSuffix(product.GetDefaultDownloadMirrorURL(), "/")
+}
@@ -0,0 +1,172 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testReleasesJSON = `{
+  "name": "myproduct",
+  "versions": {
+    "2.1.0": {
+      "name": "myproduct",
+      "version": "2.1.0",
+      "shasums": "myproduct_2.1.0_SHA256SUMS",
+      "shasums_signature": "myproduct_2.1.0_SHA256SUMS.sig",
+      "shasums_signatures": ["myproduct_2.1.0_SHA256SUMS.72D7468F.sig", "myproduct_2.1.0_SHA256SUMS.sig"],
+      "builds": [
+        {"os": "linux", "arch": "amd64", "filename": "myproduct_2.1.0_linux_x86_64.zip", "url": "https://cdn.example.com/myproduct_2.1.0_linux_x86_64.zip"},
+        {"os": "darwin", "arch": "arm64", "filename": "myproduct_2.1.0_darwin_arm64.zip", "url": "https://cdn.example.com/myproduct_2.1.0_darwin_arm64.zip"},
+        {"os": "darwin", "arch": "amd64", "filename": "myproduct_2.1.0_darwin_amd64.zip", "url": "https://cdn.example.com/myproduct_2.1.0_darwin_amd64.zip"}
+      ]
+    },
+    "2.0.0": {
+      "name": "myproduct",
+      "version": "2.0.0",
+      "shasums": "myproduct_2.0.0_SHA256SUMS",
+      "shasums_signature": "myproduct_2.0.0_SHA256SUMS.sig",
+      "builds": [
+        {"os": "linux", "arch": "amd64", "filename": "myproduct_2.0.0_linux_amd64.zip"}
+      ]
+    },
+    "1.0.0": {"name": "myproduct", "version": "1.0.0"}
+  }
+}`
+
+func setupTestReleasesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	logger = InitLogger("DEBUG")
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/myproduct/index.json" {
+			http.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+		if _, err := w.Write([]byte(testReleasesJSON)); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+func Test_GetReleaseArtifact(t *testing.T) {
+	mockServer := setupTestReleasesServer(t)
+	defer mockServer.Close()
+
+	product := newHashiCorpProduct("myproduct", "My Product")
+	product.DefaultDownloadMirror = "https://releases.example.com/myproduct"
+	mirrorURL := mockServer.URL + "/myproduct/index.json"
+
+	t.Log("Default download mirror uses published URL")
+	artifact, err := GetReleaseArtifact(product, mirrorURL, "", "2.1.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ReleaseArtifact{
+		URL:                   "https://cdn.example.com/myproduct_2.1.0_linux_x86_64.zip",
+		ChecksumsURL:          "https://releases.example.com/myproduct/2.1.0/myproduct_2.1.0_SHA256SUMS",
+		ChecksumsSignatureURL: "https://releases.example.com/myproduct/2.1.0/myproduct_2.1.0_SHA256SUMS.72D7468F.sig",
+	}
+	if artifact == nil || *artifact != expected {
+		t.Errorf("Artifact not matching. Got %+v, expected %+v", artifact, expected)
+	}
+
+	t.Log("Custom download mirror uses published file name")
+	artifact, err = GetReleaseArtifact(product, mirrorURL, "https://mirror.example.com/myproduct", "2.1.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "https://mirror.example.com/myproduct/2.1.0/myproduct_2.1.0_linux_x86_64.zip"; artifact == nil || artifact.URL != expected {
+		t.Errorf("Artifact URL not matching. Got %+v, expected %q", artifact, expected)
+	}
+
+	t.Log("Fall back to generic signature")
+	artifact, err = GetReleaseArtifact(product, mirrorURL, "", "2.0.0", "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "https://releases.example.com/myproduct/2.0.0/myproduct_2.0.0_SHA256SUMS.sig"; artifact == nil || artifact.ChecksumsSignatureURL != expected {
+		t.Errorf("Signature URL not matching. Got %+v, expected %q", artifact, expected)
+	}
+
+	t.Log("No builds metadata")
+	artifact, err = GetReleaseArtifact(product, mirrorURL, "", "1.0.0", "linux", "amd64")
+	if err != nil || artifact != nil {
+		t.Errorf("Expected no artifact and no error for release without builds. Got %+v, %v", artifact, err)
+	}
+
+	t.Log("Platform not built")
+	_, err = GetReleaseArtifact(product, mirrorURL, "", "2.1.0", "freebsd", "amd64")
+	if err == nil {
+		t.Fatal("Expected error for platform without build. Got nil")
+	}
+	if expected := "Available platforms: darwin_amd64, darwin_arm64, linux_amd64"; !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error to contain %q. Got %q", expected, err.Error())
+	}
+}
+
+func Test_GetReleaseArtifact_versions_format(t *testing.T) {
+	logger = InitLogger("DEBUG")
+	var requests int
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"versions": [{"id": "1.6.0"}]}`))
+	}))
+	defer mockServer.Close()
+	mirrorURL := mockServer.URL + "/api.json"
+
+	t.Log("Mirror not serving HashiCorp releases JSON")
+	artifact, err := GetReleaseArtifact(GetProductById("opentofu"), mirrorURL, "", "1.6.0", "linux", "amd64")
+	if err != nil || artifact != nil {
+		t.Errorf("Expected no artifact and no error for OpenTofu mirror. Got %+v, %v", artifact, err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected OpenTofu mirror not to be requested for builds metadata. Got %d requests", requests)
+	}
+
+	t.Log("Invalid HashiCorp releases JSON")
+	product := newHashiCorpProduct("myproduct", "My Product")
+	if _, err = GetReleaseArtifact(product, mirrorURL, "", "1.6.0", "linux", "amd64"); err == nil {
+		t.Error("Expected error for invalid HashiCorp releases JSON. Got nil")
+	}
+}
+
+// Test_DownloadReleaseArtifact : Test DownloadReleaseArtifact with artifact named differently from product conventions
+func Test_DownloadReleaseArtifact(t *testing.T) {
+	downloadProductTestConfig := DownloadProductTestConfig{}
+	mockServer := setupTestDownloadServer(t, &downloadProductTestConfig)
+	defer mockServer.Close()
+
+	mockProduct := TerraformProduct{
+		ProductDetails{
+			ID:             "myproduct",
+			Name:           "Mock Product",
+			VersionPrefix:  "myprod_",
+			ExecutableName: "myprod",
+			ArchivePrefix:  "unused_prefix_",
+			PublicKeyId:    downloadProductTestConfig.GpgFingerprint,
+			PublicKeyURLs:  []string{mockServer.URL + "/testproduct/gpg-key.txt"},
+		},
+	}
+
+	tempDir, err := os.MkdirTemp("", "downloadReleaseArtifact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	releaseURL := mockServer.URL + "/productdownload/2.1.0/"
+	zipFilePath, err := DownloadReleaseArtifact(mockProduct, tempDir, ReleaseArtifact{
+		URL:                   releaseURL + "my_product_download_2.1.0_linux_amd64.zip",
+		ChecksumsURL:          releaseURL + "my_product_download_2.1.0_SHA256SUMS",
+		ChecksumsSignatureURL: releaseURL + "my_product_download_2.1.0_SHA256SUMS." + downloadProductTestConfig.GpgFingerprint + ".sig",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expectedZipPath := filepath.Join(tempDir, "my_product_download_2.1.0_linux_amd64.zip"); zipFilePath != expectedZipPath {
+		t.Errorf("Returned zipFile not expected path. Expected: %q, actual: %q", expectedZipPath, zipFilePath)
+	}
+}
@@ -20,8 +20,32 @@ func GetSemver(product Product, tfconstraint string, mirrorURL string) (string,
 	return tfversion, err
 }
 
+// GetMinSemver : returns the lowest version satisfying the constraint provided
+func GetMinSemver(product Product, tfconstraint string, mirrorURL string) (string, error) {
+	tflist, errTFList := getTFList(product, mirrorURL, true) // get list of versions
+	if errTFList != nil {
+		return "", fmt.Errorf("Error getting list of versions from %q: %v", mirrorURL, errTFList)
+	}
+	logger.Infof("Reading minimum required version from constraint: %q", tfconstraint)
+	return semVerParser(&tfconstraint, tflist, true)
+}
+
+// IsVersionConstraint : whether the value is a version constraint (e.g. `~> 1.6`, `>= 1.5, < 1.8`) rather than an exact version
+func IsVersionConstraint(value string) bool {
+	if validVersionFormat(value) {
+		return false
+	}
+	_, err := semver.NewConstraint(value)
+	return err == nil
+}
+
 // SemVerParser  : Goes through the list of versions, returns a valid version for constraint provided
 func SemVerParser(tfconstraint *string, tflist []string) (string, error) {
+	return semVerParser(tfconstraint, tflist, false)
+}
+
+// semVerParser : returns the highest (or the lowest) valid version for constraint provided
+func semVerParser(tfconstraint *string, tflist []string, lowest bool) (string, error) {
 	tfversion := ""
 	constraints, err := semver.NewConstraint(*tfconstraint) // NewConstraint returns a Constraints instance that a Version instance can be checked against
 	if err != nil {
@@ -37,7 +61,11 @@ func SemVerParser(tfconstraint *string, tflist []string) (string, error) {
 		versions[i] = version
 	}
 
-	sort.Sort(sort.Reverse(semver.Collection(versions)))
+	if lowest {
+		sort.Sort(semver.Collection(versions))
+	} else {
+		sort.Sort(sort.Reverse(semver.Collection(versions)))
+	}
 
 	for _, element := range versions {
 		if constraints.Check(element) { // Validate a version against a constraint
@@ -122,9 +122,6 @@ func ChangeSymlink(binVersionPath string, binPath string) {
 //
 //nolint:gocyclo
 func ChangeProductSymlink(product Product, binVersionPath string, userBinPath string) error {
-	homedir := GetHomeDirectory() // get user's home directory
-	homeBinPath := filepath.Join(homedir, "bin", product.GetExecutableName())
-
 	var err error
 	var locationsFmt string
 
@@ -135,7 +132,10 @@ func ChangeProductSymlink(product Product, binVersionPath string, userBinPath st
 	}
 	possibleInstallLocations := []installLocations{
 		{path: userBinPath, create: false},
-		{path: homeBinPath, create: true},
+	}
+	// No fallback if the home directory cannot be resolved, e.g. in containers without HOME
+	if fallbackBinDir := GetFallbackBinDirectory(); fallbackBinDir != "" {
+		possibleInstallLocations = append(possibleInstallLocations, installLocations{path: filepath.Join(fallbackBinDir, product.GetExecutableName()), create: true})
 	}
 
 	for idx, location := range possibleInstallLocations {
@@ -202,9 +202,14 @@ func ChangeProductSymlink(product Product, binVersionPath string, userBinPath st
 					return fmt.Errorf("Could not derive absolute path to %q: %v", dirPath, errAbs)
 				}
 				absDirPath = strings.TrimRight(absDirPath, "/")
+				homedir := LookupHomeDirectory() // get user's home directory
 
 				for envPathElement := range strings.SplitSeq(os.Getenv("PATH"), ":") {
-					expandedEnvPathElement := strings.TrimRight(strings.Replace(envPathElement, "~", homedir, 1), "/")
+					expandedEnvPathElement := envPathElement
+					if homedir != "" {
+						expandedEnvPathElement = strings.Replace(envPathElement, "~", homedir, 1)
+					}
+					expandedEnvPathElement = strings.TrimRight(expandedEnvPathElement, "/")
 
 					if expandedEnvPathElement == absDirPath {
 						isDirInPath = true
@@ -102,3 +102,15 @@ func IsValidRemoteURL(urlString string) error {
 
 	return nil
 }
+
+// ParseBool : parse boolean value of environment variables and the like:
+// 1/0, t/f, true/false, y/n, yes/no, on/off (case-insensitive)
+func ParseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "t", "true", "y", "yes", "on":
+		return true, nil
+	case "0", "f", "false", "n", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("Invalid boolean value %q", value)
+}
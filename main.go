@@ -19,8 +19,16 @@ var (
 	version    string
 )
 
-//nolint:gocyclo
 func main() {
+	// Check pinned versions against the version constraints only when switching to the resolved version
+	if param_parsing.IsVersionResolvingRun(parameters) {
+		consistent := param_parsing.CheckVersionConflicts(parameters)
+		if !consistent && parameters.StrictVersion {
+			logger.Errorf("Pinned version does not satisfy version constraint (strict mode)")
+			os.Exit(param_parsing.VersionConflictExitCode)
+		}
+	}
+
 	var err error
 	switch {
 	case parameters.VersionFlag:
@@ -34,6 +42,16 @@ func main() {
 	case parameters.HelpFlag:
 		lib.UsageMessage()
 		os.Exit(0)
+	case len(parameters.ConfigCommand) > 0:
+		err = param_parsing.RunConfigCommand(os.Stdout, parameters)
+		if err == nil {
+			os.Exit(0)
+		}
+	case parameters.ExplainFormat != "":
+		err = param_parsing.WriteExplanation(os.Stdout, parameters)
+		if err == nil {
+			os.Exit(0)
+		}
 	case parameters.MatchVersionRequirement != "":
 		var matchRes bool
 		matchRes, err = param_parsing.MatchVersionRequirement(parameters)
@@ -45,37 +63,80 @@ func main() {
 				os.Exit(2)
 			}
 		}
-	case parameters.ListAllFlag:
+	case len(parameters.ProductsParams) > 0:
+		err = runProducts(parameters.ProductsParams)
+	default:
+		err = run(parameters)
+	}
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// run : resolve, install and switch a single product
+//
+//nolint:gocyclo
+func run(params param_parsing.Params) error {
+	var err error
+	switch {
+	case params.ListAllFlag:
 		/* show all terraform version including betas and RCs*/
-		err = lib.InstallProductOption(parameters.ProductEntity, true, parameters.DryRun, parameters.ShowRequiredFlag, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch)
-	case parameters.LatestPre != "":
+		err = lib.InstallProductOption(params.ProductEntity, true, params.DryRun, params.ShowRequiredFlag, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch)
+	case params.LatestPre != "":
 		/* latest pre-release implicit version. Ex: tfswitch --latest-pre 0.13 downloads 0.13.0-rc1 (latest) */
-		err = lib.InstallLatestProductImplicitVersion(parameters.ProductEntity, parameters.DryRun, parameters.ShowRequiredFlag, parameters.LatestPre, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch, true)
-	case parameters.ShowLatestPre != "":
+		err = lib.InstallLatestProductImplicitVersion(params.ProductEntity, params.DryRun, params.ShowRequiredFlag, params.LatestPre, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch, true)
+	case params.ShowLatestPre != "":
 		/* show latest pre-release implicit version. Ex: tfswitch --latest-pre 0.13 downloads 0.13.0-rc1 (latest) */
-		lib.ShowLatestImplicitVersion(parameters.ProductEntity, parameters.ShowLatestPre, parameters.MirrorURL, true)
-	case parameters.LatestStable != "":
+		lib.ShowLatestImplicitVersion(params.ProductEntity, params.ShowLatestPre, params.MirrorURL, true)
+	case params.LatestStable != "":
 		/* latest implicit version. Ex: tfswitch --latest-stable 0.13 downloads 0.13.5 (latest) */
-		err = lib.InstallLatestProductImplicitVersion(parameters.ProductEntity, parameters.DryRun, parameters.ShowRequiredFlag, parameters.LatestStable, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch, false)
-	case parameters.ShowLatestStable != "":
+		err = lib.InstallLatestProductImplicitVersion(params.ProductEntity, params.DryRun, params.ShowRequiredFlag, params.LatestStable, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch, false)
+	case params.ShowLatestStable != "":
 		/* show latest implicit stable version. Ex: tfswitch --show-latest-stable 0.13 downloads 0.13.5 (latest) */
-		lib.ShowLatestImplicitVersion(parameters.ProductEntity, parameters.ShowLatestStable, parameters.MirrorURL, false)
-	case parameters.LatestFlag:
+		lib.ShowLatestImplicitVersion(params.ProductEntity, params.ShowLatestStable, params.MirrorURL, false)
+	case params.LatestFlag:
 		/* latest stable version */
-		err = lib.InstallLatestProductVersion(parameters.ProductEntity, parameters.DryRun, parameters.ShowRequiredFlag, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch)
-	case parameters.ShowLatestFlag:
+		err = lib.InstallLatestProductVersion(params.ProductEntity, params.DryRun, params.ShowRequiredFlag, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch)
+	case params.ShowLatestFlag:
 		/* show latest stable version */
-		lib.ShowLatestVersion(parameters.ProductEntity, parameters.MirrorURL)
-	case parameters.Version != "":
-		err = lib.InstallProductVersion(parameters.ProductEntity, parameters.DryRun, parameters.ShowRequiredFlag, parameters.Version, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch)
-	case parameters.DefaultVersion != "":
+		lib.ShowLatestVersion(params.ProductEntity, params.MirrorURL)
+	case params.Version != "":
+		err = lib.InstallProductVersion(params.ProductEntity, params.DryRun, params.ShowRequiredFlag, params.Version, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch)
+	case params.DefaultVersion != "":
 		/* if default version is provided - Pick this instead of going for prompt */
-		err = lib.InstallProductVersion(parameters.ProductEntity, parameters.DryRun, parameters.ShowRequiredFlag, parameters.DefaultVersion, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch)
+		err = lib.InstallProductVersion(params.ProductEntity, params.DryRun, params.ShowRequiredFlag, params.DefaultVersion, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch)
 	default:
 		// Set list all false - only official release will be displayed
-		err = lib.InstallProductOption(parameters.ProductEntity, false, parameters.DryRun, parameters.ShowRequiredFlag, parameters.CustomBinaryPath, parameters.InstallPath, parameters.MirrorURL, parameters.MirrorDownloadURL, parameters.Arch)
+		err = lib.InstallProductOption(params.ProductEntity, false, params.DryRun, params.ShowRequiredFlag, params.CustomBinaryPath, params.InstallPath, params.MirrorURL, params.MirrorDownloadURL, params.Arch)
 	}
-	if err != nil {
-		logger.Fatal(err)
+	return err
+}
+
+// runProducts : run for each of the products and log combined summary
+func runProducts(productsParams []param_parsing.Params) error {
+	var summary []string
+	var failed int
+	for _, params := range productsParams {
+		logger.Infof("Switching %s", params.ProductEntity.GetName())
+		result := "OK"
+		if err := run(params); err != nil {
+			logger.Error(err)
+			result = fmt.Sprintf("FAILED: %v", err)
+			failed++
+		}
+		productVersion := params.Version
+		if productVersion == "" {
+			productVersion = "(not pinned)"
+		}
+		summary = append(summary, fmt.Sprintf("%s %s: %s", params.ProductEntity.GetName(), productVersion, result))
+	}
+
+	logger.Info("Summary:")
+	for _, line := range summary {
+		logger.Infof("  %s", line)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to switch %d of %d products", failed, len(productsParams))
 	}
+	return nil
 }